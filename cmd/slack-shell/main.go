@@ -3,22 +3,75 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/polidog/slack-shell/internal/app"
 	"github.com/polidog/slack-shell/internal/config"
+	"github.com/polidog/slack-shell/internal/oauth"
+	"github.com/polidog/slack-shell/internal/ui/styles"
 	"github.com/polidog/slack-shell/internal/version"
+	"gopkg.in/yaml.v3"
 )
 
 func main() {
+	// --json, --no-color and --scopes may appear anywhere
+	jsonOutput := false
+	noColor := styles.NoColorRequested()
+	var scopes []string
+	args := os.Args[:1]
+	for i := 1; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch {
+		case arg == "--json":
+			jsonOutput = true
+		case arg == "--no-color":
+			noColor = true
+		case arg == "--scopes" && i+1 < len(os.Args):
+			i++
+			scopes = oauth.ParseScopes(os.Args[i])
+		case strings.HasPrefix(arg, "--scopes="):
+			scopes = oauth.ParseScopes(strings.TrimPrefix(arg, "--scopes="))
+		default:
+			args = append(args, arg)
+		}
+	}
+	os.Args = args
+
+	if noColor {
+		styles.DisableColor()
+	}
+
 	// Check for version command
 	if len(os.Args) > 1 && (os.Args[1] == "version" || os.Args[1] == "--version" || os.Args[1] == "-v") {
 		fmt.Println(version.String())
 		return
 	}
 
-	// Check for logout command
+	// Check for login command (login [--force] [--scopes ...])
+	if len(os.Args) > 1 && os.Args[1] == "login" {
+		force := false
+		for _, arg := range os.Args[2:] {
+			if arg != "--force" && arg != "-f" {
+				fmt.Fprintf(os.Stderr, "Error: unknown argument %q\n", arg)
+				fmt.Fprintln(os.Stderr, "Usage: slack-shell login [--force] [--scopes SCOPES]")
+				os.Exit(1)
+			}
+			force = true
+		}
+		if err := app.Login(force, scopes); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Check for logout command (logout [workspace])
 	if len(os.Args) > 1 && os.Args[1] == "logout" {
-		if err := app.Logout(); err != nil {
+		workspace := ""
+		if len(os.Args) > 2 {
+			workspace = os.Args[2]
+		}
+		if err := app.Logout(workspace); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -47,23 +100,113 @@ func main() {
 			fmt.Printf("Config file created at %s\n", configPath)
 			return
 		}
+		if len(os.Args) > 2 && os.Args[2] == "path" {
+			configPath, exists, err := config.ResolveConfigPath()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			switch {
+			case !exists:
+				fmt.Printf("Config:      none found (would be created at %s)\n", configPath)
+			case config.IsLegacyPath(configPath):
+				fmt.Printf("Config:      %s (legacy location)\n", configPath)
+			default:
+				fmt.Printf("Config:      %s (XDG location)\n", configPath)
+			}
+			credPaths := config.CredentialsPaths()
+			if len(credPaths) == 0 {
+				fmt.Println("Credentials: none found")
+			}
+			for i, path := range credPaths {
+				label := "Credentials: "
+				if i > 0 {
+					label = "             "
+				}
+				if config.IsLegacyPath(path) {
+					path += " (legacy location)"
+				}
+				fmt.Println(label + path)
+			}
+			if cacheDir, err := config.GetCacheDir(); err == nil {
+				fmt.Printf("Cache:       %s\n", cacheDir)
+			}
+			return
+		}
+		if len(os.Args) > 2 && os.Args[2] == "show" {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			for _, w := range cfg.Warnings {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+			}
+			data, err := yaml.Marshal(cfg.Masked())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Print(string(data))
+			return
+		}
+		if len(os.Args) > 2 && os.Args[2] == "validate" {
+			path := ""
+			if len(os.Args) > 3 {
+				path = os.Args[3]
+			} else {
+				configPath, exists, err := config.ResolveConfigPath()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				if !exists {
+					fmt.Printf("%s: not found (using defaults)\n", configPath)
+					return
+				}
+				path = configPath
+			}
+			cfg, err := config.LoadFromPath(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+				os.Exit(1)
+			}
+			for _, w := range cfg.Warnings {
+				fmt.Println(w)
+			}
+			if len(cfg.Warnings) > 0 {
+				fmt.Printf("%d problem(s) found\n", len(cfg.Warnings))
+				os.Exit(1)
+			}
+			fmt.Printf("%s: OK\n", path)
+			return
+		}
 		// Show config subcommand help
 		fmt.Println("Usage: slack-shell config <subcommand>")
 		fmt.Println("")
 		fmt.Println("Subcommands:")
 		fmt.Println("  init [path] [--force]  Create a sample config file")
+		fmt.Println("  path                   Show the config, credentials and cache locations in use")
+		fmt.Println("  show                   Show the effective config (secrets masked)")
+		fmt.Println("  validate [path]        Check a config file for mistakes")
 		fmt.Println("")
 		fmt.Println("Examples:")
 		fmt.Println("  slack-shell config init                    # Create at ~/.slack-shell/config.yaml")
 		fmt.Println("  slack-shell config init ~/work.yaml        # Create at specified path")
 		fmt.Println("  slack-shell config init ~/work.yaml -f     # Overwrite if exists")
+		fmt.Println("  slack-shell config path                    # Print the files in use")
+		fmt.Println("  slack-shell config validate                # Check the config file in use")
 		return
 	}
 
 	// Check for -c option (execute command and exit)
 	if len(os.Args) > 2 && os.Args[1] == "-c" {
 		command := os.Args[2]
-		application, err := app.New(app.WithNonInteractive())
+		opts := []app.Option{app.WithNonInteractive()}
+		if jsonOutput {
+			opts = append(opts, app.WithJSONOutput())
+		}
+		application, err := app.New(opts...)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -72,12 +215,18 @@ func main() {
 
 		if err := application.RunCommand(command); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			application.Stop() // os.Exit skips deferred calls
 			os.Exit(1)
 		}
 		return
 	}
 
-	application, err := app.New()
+	var opts []app.Option
+	if len(scopes) > 0 {
+		opts = append(opts, app.WithUserScopes(scopes))
+	}
+
+	application, err := app.New(opts...)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -86,6 +235,7 @@ func main() {
 
 	if err := application.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		application.Stop() // os.Exit skips deferred calls
 		os.Exit(1)
 	}
 }
@@ -1,9 +1,13 @@
 package app
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/polidog/slack-shell/internal/cache"
@@ -12,10 +16,12 @@ import (
 	"github.com/polidog/slack-shell/internal/oauth"
 	"github.com/polidog/slack-shell/internal/shell"
 	"github.com/polidog/slack-shell/internal/slack"
+	"github.com/polidog/slack-shell/internal/ui/styles"
 )
 
 type App struct {
 	config              *config.Config
+	userScopes          []string
 	slackClient         *slack.Client
 	realtimeClient      *slack.RealtimeClient
 	notificationManager *notification.Manager
@@ -24,11 +30,19 @@ type App struct {
 	model               *shell.Model
 	program             *tea.Program
 	nonInteractive      bool
+	jsonOutput          bool
 }
 
 // Option is a functional option for App
 type Option func(*App)
 
+// WithUserScopes overrides the OAuth user scopes requested at login
+func WithUserScopes(scopes []string) Option {
+	return func(a *App) {
+		a.userScopes = scopes
+	}
+}
+
 // WithNonInteractive sets the app to non-interactive mode (suppresses startup messages)
 func WithNonInteractive() Option {
 	return func(a *App) {
@@ -36,6 +50,14 @@ func WithNonInteractive() Option {
 	}
 }
 
+// WithJSONOutput makes RunCommand print structured JSON for commands that
+// support it
+func WithJSONOutput() Option {
+	return func(a *App) {
+		a.jsonOutput = true
+	}
+}
+
 func New(opts ...Option) (*App, error) {
 	app := &App{}
 	for _, opt := range opts {
@@ -45,6 +67,12 @@ func New(opts ...Option) (*App, error) {
 	if err != nil {
 		return nil, fmt.Errorf("設定の読み込みに失敗しました: %w", err)
 	}
+	for _, w := range cfg.Warnings {
+		fmt.Fprintf(os.Stderr, "警告: %s\n", w)
+	}
+	if len(app.userScopes) > 0 {
+		cfg.UserScopes = app.userScopes
+	}
 
 	// Get tokens
 	token, botToken, err := getTokens(cfg, app.nonInteractive)
@@ -93,12 +121,17 @@ func getTokens(cfg *config.Config, nonInteractive bool) (string, string, error)
 	}
 
 	// 2. Check for saved credentials
-	creds, err := config.LoadCredentials()
-	if err == nil && creds.AccessToken != "" {
-		if !nonInteractive {
-			fmt.Printf("保存済みの認証情報を使用します (ワークスペース: %s)\n", creds.TeamName)
+	if all, err := config.LoadCredentials(); err == nil {
+		creds, err := selectCredentials(all, cfg.DefaultWorkspace, nonInteractive)
+		if err != nil {
+			return "", "", err
+		}
+		if creds.AccessToken != "" {
+			if !nonInteractive {
+				fmt.Printf("保存済みの認証情報を使用します (ワークスペース: %s)\n", creds.TeamName)
+			}
+			return creds.AccessToken, creds.BotToken, nil
 		}
-		return creds.AccessToken, creds.BotToken, nil
 	}
 
 	// 3. OAuth flow
@@ -150,13 +183,59 @@ func getTokens(cfg *config.Config, nonInteractive bool) (string, string, error)
    client_secret: your-client-secret`)
 }
 
+// selectCredentials picks the saved workspace to use: the configured
+// default, the only one saved, or one chosen at a prompt
+func selectCredentials(all []*config.Credentials, workspace string, nonInteractive bool) (*config.Credentials, error) {
+	if workspace != "" {
+		if creds := config.FindCredentials(all, workspace); creds != nil {
+			return creds, nil
+		}
+		return nil, fmt.Errorf("default_workspace に指定したワークスペースが見つかりません: %s", workspace)
+	}
+	if len(all) == 1 {
+		return all[0], nil
+	}
+
+	names := make([]string, len(all))
+	for i, creds := range all {
+		names[i] = creds.TeamName
+	}
+	if nonInteractive {
+		return nil, fmt.Errorf("複数のワークスペースにログインしています (%s)。設定ファイルの default_workspace で選択してください", strings.Join(names, ", "))
+	}
+
+	fmt.Println("ワークスペースを選択してください:")
+	for i, name := range names {
+		fmt.Printf("  %d) %s\n", i+1, name)
+	}
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("番号または名前: ")
+		line, err := reader.ReadString('\n')
+		answer := strings.TrimSpace(line)
+		if n, convErr := strconv.Atoi(answer); convErr == nil && n >= 1 && n <= len(all) {
+			return all[n-1], nil
+		}
+		if creds := config.FindCredentials(all, answer); creds != nil {
+			return creds, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ワークスペースが選択されませんでした")
+		}
+		fmt.Println("該当するワークスペースがありません。")
+	}
+}
+
 func (a *App) Run() error {
 	// Initialize notification manager
 	notifyCfg := a.config.GetNotificationConfig()
 	a.notificationManager = notification.NewManager(notifyCfg)
 
+	styles.SetTheme(a.config.GetTheme())
 	model := shell.NewModel(a.slackClient, a.notificationManager, a.config.GetPromptConfig(), a.config.GetDisplayConfig(), a.config.GetStartupConfig(), a.config.AppToken != "")
 	a.model = model
+	model.SetKeymap(a.config.GetKeymap())
+	model.SetCompletionConfig(a.config.GetCompletionConfig())
 
 	// Set caches if available
 	if a.userCache != nil {
@@ -188,6 +267,7 @@ func (a *App) Run() error {
 			},
 			a.config.Debug,
 		)
+		a.realtimeClient.SetMaxRetries(a.config.RealtimeMaxRetries)
 		model.SetRealtimeClient(a.realtimeClient)
 
 		go func() {
@@ -195,7 +275,12 @@ func (a *App) Run() error {
 				fmt.Fprintf(os.Stderr, "[DEBUG] Starting Socket Mode connection...\n")
 			}
 			if err := a.realtimeClient.Start(); err != nil {
-				fmt.Fprintf(os.Stderr, "[ERROR] Socket Mode error: %v\n", err)
+				// Show the error in the shell rather than writing over the UI
+				if a.program != nil {
+					a.program.Send(shell.ConnectionStatusMsg{Err: err})
+				} else {
+					fmt.Fprintf(os.Stderr, "[ERROR] Socket Mode error: %v\n", err)
+				}
 			}
 		}()
 	}
@@ -203,21 +288,48 @@ func (a *App) Run() error {
 	a.program = tea.NewProgram(model)
 
 	_, err := a.program.Run()
+
+	// Flush caches as soon as the UI exits so resolved names survive even
+	// if the process is terminated before Stop runs
+	a.saveCaches()
 	return err
 }
 
-func (a *App) Stop() {
-	// Save caches
-	if a.userCache != nil {
-		if err := a.userCache.Save(); err != nil {
+// saveCaches writes dirty user/channel caches to disk. The caches in use by
+// the shell take precedence, since a workspace switch replaces them.
+func (a *App) saveCaches() {
+	userCache, channelCache := a.userCache, a.channelCache
+	if a.model != nil {
+		executor := a.model.GetExecutor()
+		if c := executor.GetUserCache(); c != nil {
+			userCache = c
+		}
+		if c := executor.GetChannelCache(); c != nil {
+			channelCache = c
+		}
+	}
+
+	if userCache != nil {
+		if a.config.Debug && userCache.IsDirty() {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Flushing user cache (%d entries)\n", userCache.Size())
+		}
+		if err := userCache.Save(); err != nil {
 			log.Printf("Warning: failed to save user cache: %v", err)
 		}
 	}
-	if a.channelCache != nil {
-		if err := a.channelCache.Save(); err != nil {
+	if channelCache != nil {
+		if a.config.Debug && channelCache.IsDirty() {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Flushing channel cache (%d channels, %d DMs)\n", channelCache.ChannelsSize(), channelCache.DMsSize())
+		}
+		if err := channelCache.Save(); err != nil {
 			log.Printf("Warning: failed to save channel cache: %v", err)
 		}
 	}
+}
+
+func (a *App) Stop() {
+	// Save caches
+	a.saveCaches()
 
 	if a.realtimeClient != nil {
 		a.realtimeClient.Stop()
@@ -227,11 +339,69 @@ func (a *App) Stop() {
 	}
 }
 
-// Logout removes saved credentials
-func Logout() error {
-	if err := config.DeleteCredentials(); err != nil {
+// Login runs the OAuth flow and saves the credentials of the workspace
+// signed in to; other workspaces are kept. Saved credentials of that
+// workspace are only replaced when force is set. scopes overrides the user
+// scopes requested, if set.
+func Login(force bool, scopes []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("設定の読み込みに失敗しました: %w", err)
+	}
+	if len(scopes) > 0 {
+		cfg.UserScopes = scopes
+	}
+	if !cfg.HasOAuthConfig() {
+		return fmt.Errorf("OAuth設定がありません。SLACK_CLIENT_ID と SLACK_CLIENT_SECRET (または設定ファイルの client_id / client_secret) を設定してください")
+	}
+
+	// The workspace is only known after signing in, so list the saved ones
+	// up front
+	all, _ := config.LoadCredentials()
+	if !force && len(all) > 0 {
+		names := make([]string, len(all))
+		for i, creds := range all {
+			names[i] = creds.TeamName
+		}
+		fmt.Printf("保存済みのワークスペース: %s (上書きするには --force を指定してください)\n", strings.Join(names, ", "))
+	}
+
+	fmt.Println("OAuth認証を開始します...")
+	oauthFlow, err := oauth.NewOAuthFlow(cfg)
+	if err != nil {
+		return fmt.Errorf("OAuth初期化に失敗しました: %w", err)
+	}
+	creds, err := oauthFlow.Start()
+	if err != nil {
+		return fmt.Errorf("OAuth認証に失敗しました: %w", err)
+	}
+
+	replaced := config.FindCredentials(all, creds.TeamID) != nil
+	if replaced && !force {
+		return fmt.Errorf("ワークスペース %s の認証情報は既に保存されています (--force で上書きできます)", creds.TeamName)
+	}
+
+	if err := config.SaveCredentials(creds); err != nil {
+		return fmt.Errorf("認証情報の保存に失敗しました: %w", err)
+	}
+	if replaced {
+		fmt.Printf("ログインしました (ワークスペース: %s、保存済みの認証情報を置き換えました)\n", creds.TeamName)
+		return nil
+	}
+	fmt.Printf("ログインしました (ワークスペース: %s)\n", creds.TeamName)
+	return nil
+}
+
+// Logout removes saved credentials for workspace (team name or ID), or for
+// every workspace when workspace is empty
+func Logout(workspace string) error {
+	if err := config.DeleteCredentials(workspace); err != nil {
 		return fmt.Errorf("ログアウトに失敗しました: %w", err)
 	}
+	if workspace != "" {
+		fmt.Printf("ログアウトしました (ワークスペース: %s)。\n", workspace)
+		return nil
+	}
 	fmt.Println("ログアウトしました。")
 	return nil
 }
@@ -239,12 +409,24 @@ func Logout() error {
 // RunCommand executes a command string and exits (non-interactive mode)
 func (a *App) RunCommand(commandStr string) error {
 	executor := shell.NewExecutorWithCache(a.slackClient, a.config.GetPromptConfig(), a.config.GetDisplayConfig(), a.config.AppToken != "", a.userCache, a.channelCache)
+	if a.jsonOutput {
+		executor.SetOutputFormat(shell.OutputJSON)
+	}
+
+	// Split by && or ; for multiple commands. A command after && is skipped
+	// when the previous one failed; after ; it runs regardless. Errors are
+	// returned together once the chain is done.
+	commands := shell.SplitCommands(commandStr)
 
-	// Split by && or ; for multiple commands
-	commands := splitCommands(commandStr)
+	var errs []error
+	failed := false
+	for _, chained := range commands {
+		if chained.Conditional && failed {
+			continue
+		}
+		failed = false
 
-	for _, cmdStr := range commands {
-		cmdStr = trimSpace(cmdStr)
+		cmdStr := trimSpace(chained.Input)
 		if cmdStr == "" {
 			continue
 		}
@@ -258,8 +440,13 @@ func (a *App) RunCommand(commandStr string) error {
 		// Execute the pipeline
 		result := executor.ExecutePipeline(pipeline)
 
+		if result.Error == nil && result.Confirm != nil {
+			result.Error = fmt.Errorf("confirmation required in non-interactive mode: %s(use --yes to skip)", result.Confirm.Prompt)
+		}
 		if result.Error != nil {
-			return result.Error
+			errs = append(errs, result.Error)
+			failed = true
+			continue
 		}
 
 		if result.Output != "" {
@@ -276,55 +463,7 @@ func (a *App) RunCommand(commandStr string) error {
 		log.Printf("Warning: failed to save user cache: %v", err)
 	}
 
-	return nil
-}
-
-// splitCommands splits a command string by && or ;
-func splitCommands(s string) []string {
-	var result []string
-	var current string
-	inQuote := false
-	quoteChar := rune(0)
-
-	for i, r := range s {
-		if (r == '"' || r == '\'') && (i == 0 || s[i-1] != '\\') {
-			if !inQuote {
-				inQuote = true
-				quoteChar = r
-			} else if r == quoteChar {
-				inQuote = false
-			}
-			current += string(r)
-			continue
-		}
-
-		if !inQuote {
-			// Check for &&
-			if r == '&' && i+1 < len(s) && s[i+1] == '&' {
-				result = append(result, current)
-				current = ""
-				continue
-			}
-			// Skip the second &
-			if r == '&' && i > 0 && s[i-1] == '&' {
-				continue
-			}
-			// Check for ;
-			if r == ';' {
-				result = append(result, current)
-				current = ""
-				continue
-			}
-		}
-
-		current += string(r)
-	}
-
-	if current != "" {
-		result = append(result, current)
-	}
-
-	return result
+	return errors.Join(errs...)
 }
 
 // trimSpace removes leading and trailing whitespace
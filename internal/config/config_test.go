@@ -0,0 +1,48 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadWarnsOnUnknownKeys(t *testing.T) {
+	dir, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "slack-shell", "config.yaml")
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	fromPath, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	for name, cfg := range map[string]*Config{"LoadFromPath": fromPath, "Load": loaded} {
+		if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0], "unknown key notificatons") {
+			t.Errorf("%s warnings = %q, want one about notificatons", name, cfg.Warnings)
+		}
+		if got := cfg.GetDisplayConfig().NameFormat; got != "real_name" {
+			t.Errorf("%s name_format = %q, want the rest of the file to load", name, got)
+		}
+	}
+}
+
+func TestDecodeYAMLWarnsOnUnknownThemeKeys(t *testing.T) {
+	var cfg Config
+	warnings, err := decodeYAML([]byte("theme:\n  preset: light\n  forground: \"235\"\n"), &cfg)
+	if err != nil {
+		t.Fatalf("decodeYAML() error = %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != "line 3: unknown key forground" {
+		t.Errorf("warnings = %q, want one about forground on line 3", warnings)
+	}
+	if cfg.Theme == nil || cfg.Theme.Preset != "light" {
+		t.Errorf("theme = %+v, want the known keys decoded", cfg.Theme)
+	}
+}
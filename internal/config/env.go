@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+)
+
+// envRefPattern matches ${VAR} and ${env:VAR} references in config values
+var envRefPattern = regexp.MustCompile(`\$\{(?:env:)?([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnvRefs replaces ${VAR} and ${env:VAR} in every string field of cfg
+// with the environment variable's value. Other text, including a bare $VAR,
+// is left as written. It returns a warning for each variable that is unset.
+func expandEnvRefs(cfg *Config) []string {
+	var warnings []string
+	seen := make(map[string]bool)
+	expandEnvValue(reflect.ValueOf(cfg).Elem(), func(s string) string {
+		return envRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+			name := envRefPattern.FindStringSubmatch(ref)[1]
+			value, ok := os.LookupEnv(name)
+			if !ok && !seen[name] {
+				seen[name] = true
+				warnings = append(warnings, fmt.Sprintf("environment variable %s is not set", name))
+			}
+			return value
+		})
+	})
+	return warnings
+}
+
+func expandEnvValue(v reflect.Value, expand func(string) string) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			expandEnvValue(v.Elem(), expand)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				expandEnvValue(v.Field(i), expand)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			expandEnvValue(v.Index(i), expand)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(expand(v.String()))
+		}
+	}
+}
@@ -41,6 +41,13 @@ const (
 	ActionNextMatch  Action = "next_match"
 	ActionPrevMatch  Action = "prev_match"
 
+	// Message actions
+	ActionEdit          Action = "edit"
+	ActionDelete        Action = "delete"
+	ActionNotifications Action = "notifications"
+	ActionPermalink     Action = "permalink"
+	ActionYank          Action = "yank"
+
 	// Misc
 	ActionRefresh Action = "refresh"
 	ActionHelp    Action = "help"
@@ -81,6 +88,13 @@ type KeyBindings struct {
 	NextMatch []string `yaml:"next_match"`
 	PrevMatch []string `yaml:"prev_match"`
 
+	// Message actions
+	Edit          []string `yaml:"edit"`
+	Delete        []string `yaml:"delete"`
+	Notifications []string `yaml:"notifications"`
+	Permalink     []string `yaml:"permalink"`
+	Yank          []string `yaml:"yank"`
+
 	// Misc
 	Refresh []string `yaml:"refresh"`
 	Help    []string `yaml:"help"`
@@ -122,6 +136,13 @@ func DefaultKeyBindings() *KeyBindings {
 		NextMatch: []string{"n"},
 		PrevMatch: []string{"N"},
 
+		// Message actions
+		Edit:          []string{"e"},
+		Delete:        []string{"d"},
+		Notifications: []string{"n"},
+		Permalink:     []string{"Y"},
+		Yank:          []string{"y"},
+
 		// Misc
 		Refresh: []string{"ctrl+r", "R"},
 		Help:    []string{"?"},
@@ -184,6 +205,12 @@ func (km *Keymap) buildActionMap() {
 	addKeys(km.bindings.NextMatch, ActionNextMatch)
 	addKeys(km.bindings.PrevMatch, ActionPrevMatch)
 
+	addKeys(km.bindings.Edit, ActionEdit)
+	addKeys(km.bindings.Delete, ActionDelete)
+	addKeys(km.bindings.Notifications, ActionNotifications)
+	addKeys(km.bindings.Permalink, ActionPermalink)
+	addKeys(km.bindings.Yank, ActionYank)
+
 	addKeys(km.bindings.Refresh, ActionRefresh)
 	addKeys(km.bindings.Help, ActionHelp)
 }
@@ -294,6 +321,21 @@ func (km *KeyBindings) Merge(other *KeyBindings) {
 	if len(other.PrevMatch) > 0 {
 		km.PrevMatch = other.PrevMatch
 	}
+	if len(other.Edit) > 0 {
+		km.Edit = other.Edit
+	}
+	if len(other.Delete) > 0 {
+		km.Delete = other.Delete
+	}
+	if len(other.Notifications) > 0 {
+		km.Notifications = other.Notifications
+	}
+	if len(other.Permalink) > 0 {
+		km.Permalink = other.Permalink
+	}
+	if len(other.Yank) > 0 {
+		km.Yank = other.Yank
+	}
 	if len(other.Refresh) > 0 {
 		km.Refresh = other.Refresh
 	}
@@ -0,0 +1,40 @@
+package keymap
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// namedKeys holds the names bubbletea gives keys that aren't a single
+// character ("enter", "ctrl+a", "pgup", ...)
+var namedKeys = func() map[string]bool {
+	names := make(map[string]bool)
+	for t := tea.KeyType(-512); t < 512; t++ {
+		if name := t.String(); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}()
+
+// ValidKey reports whether key is a key name the shell can receive: a single
+// character or a named key, optionally prefixed with "alt+"
+func ValidKey(key string) bool {
+	key = strings.TrimPrefix(key, "alt+")
+	return namedKeys[key] || utf8.RuneCountInString(key) == 1
+}
+
+// InvalidKeys returns the bound keys that no key press can match, sorted
+func (km *KeyBindings) InvalidKeys() []string {
+	var invalid []string
+	for key := range New(km).actionMap {
+		if !ValidKey(key) {
+			invalid = append(invalid, key)
+		}
+	}
+	sort.Strings(invalid)
+	return invalid
+}
@@ -2,6 +2,17 @@ package notification
 
 import (
 	"fmt"
+	"time"
+)
+
+const (
+	// audibleBell is the BEL character
+	audibleBell = "\a"
+
+	// Visual bell: switch the screen to reverse video and back (DECSCNM)
+	visualBellOn  = "\x1b[?5h"
+	visualBellOff = "\x1b[?5l"
+	visualBellFor = 100 * time.Millisecond
 )
 
 // BellNotifier sends terminal bell notifications
@@ -22,8 +33,18 @@ func (b *BellNotifier) Notify(msg Message) error {
 		return nil
 	}
 
-	// Print the bell character to trigger terminal bell
-	fmt.Print("\a")
+	switch {
+	case b.config.Sequence != "":
+		fmt.Print(b.config.Sequence)
+	case b.config.VisualBell:
+		fmt.Print(visualBellOn)
+		time.AfterFunc(visualBellFor, func() {
+			fmt.Print(visualBellOff)
+		})
+	default:
+		// Print the bell character to trigger terminal bell
+		fmt.Print(audibleBell)
+	}
 	return nil
 }
 
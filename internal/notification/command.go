@@ -0,0 +1,119 @@
+package notification
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/polidog/slack-shell/internal/platform"
+)
+
+// CommandNotifier runs a user-defined command for notifications
+type CommandNotifier struct {
+	config *CommandConfig
+}
+
+// NewCommandNotifier creates a new command notifier
+func NewCommandNotifier(cfg *CommandConfig) *CommandNotifier {
+	return &CommandNotifier{
+		config: cfg,
+	}
+}
+
+// Notify runs the command template without waiting for it to finish
+func (c *CommandNotifier) Notify(msg Message) error {
+	if !c.config.Enabled || c.config.Template == "" {
+		return nil
+	}
+
+	cmd := c.command(msg)
+	if cmd == nil {
+		return nil
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to run notification command: %w", err)
+	}
+	go func() {
+		_ = cmd.Wait()
+	}()
+	return nil
+}
+
+// command builds the notification command. The template is split into
+// arguments first and the placeholders are filled in per argument, without
+// a shell, so message text is never interpreted. The values are also in
+// SLACK_SHELL_CHANNEL, SLACK_SHELL_USER and SLACK_SHELL_TEXT.
+func (c *CommandNotifier) command(msg Message) *exec.Cmd {
+	args := splitArgs(c.config.Template)
+	if len(args) == 0 {
+		return nil
+	}
+
+	channel := "#" + msg.ChannelName
+	if msg.IsIM {
+		channel = "@" + msg.UserName
+	}
+	replacer := strings.NewReplacer(
+		"{channel}", channel,
+		"{user}", msg.UserName,
+		"{text}", msg.Text,
+	)
+	for i, arg := range args {
+		if i == 0 {
+			if expanded, err := platform.ExpandPath(arg); err == nil {
+				arg = expanded
+			}
+		}
+		args[i] = replacer.Replace(arg)
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = append(os.Environ(),
+		"SLACK_SHELL_CHANNEL="+channel,
+		"SLACK_SHELL_USER="+msg.UserName,
+		"SLACK_SHELL_TEXT="+msg.Text,
+	)
+	return cmd
+}
+
+// Close cleans up resources
+func (c *CommandNotifier) Close() {
+	// No cleanup needed
+}
+
+// splitArgs splits a command template into arguments at whitespace. Single
+// or double quotes group words into one argument and are removed; nothing
+// inside them is expanded.
+func splitArgs(s string) []string {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"say {user} in {channel}", []string{"say", "{user}", "in", "{channel}"}},
+		{`notify-send "{user}: {text}"`, []string{"notify-send", "{user}: {text}"}},
+		{`echo '{text}'  done`, []string{"echo", "{text}", "done"}},
+		{`a ""`, []string{"a", ""}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := splitArgs(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitArgs(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCommandNotifierDoesNotRunMessageText(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses echo from the PATH")
+	}
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	templates := []string{
+		"echo {user}: {text}",
+		`echo "{user}: {text}"`,
+		`echo '{user}: {text}'`,
+	}
+	payloads := []string{"$(touch x)", "`touch x`", "'; touch x; '", `"; touch x; "`}
+	for _, template := range templates {
+		for _, text := range payloads {
+			n := NewCommandNotifier(&CommandConfig{Enabled: true, Template: template})
+			cmd := n.command(Message{ChannelName: "general", UserName: "alice", Text: text})
+			if cmd == nil {
+				t.Fatalf("command(%q) = nil", template)
+			}
+			if got := cmd.Args[len(cmd.Args)-1]; got != text && got != "alice: "+text {
+				t.Errorf("template %q: last argument = %q, want the text unchanged", template, got)
+			}
+			if err := cmd.Run(); err != nil {
+				t.Fatalf("template %q: %v", template, err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "x")); err == nil {
+				t.Fatalf("template %q ran message text %q", template, text)
+			}
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package notification
 
+import (
+	"strings"
+	"time"
+)
+
 // Config holds all notification configuration
 type Config struct {
 	Enabled bool `yaml:"enabled"`
@@ -8,21 +13,131 @@ type Config struct {
 	Desktop DesktopConfig `yaml:"desktop"`
 	Title   TitleConfig   `yaml:"title"`
 	Visual  VisualConfig  `yaml:"visual"`
+	Command CommandConfig `yaml:"command"`
+
+	MuteChannels []string          `yaml:"mute_channels"`
+	DND          bool              `yaml:"dnd"`
+	QuietHours   *QuietHoursConfig `yaml:"quiet_hours"`
+
+	// Keywords notify like mentions (also in mentions_only mode) and are
+	// highlighted in browse/live. Matching is case-insensitive; with
+	// KeywordsWholeWord set, "deploy" does not match "deployment".
+	Keywords          []string `yaml:"keywords"`
+	KeywordsWholeWord bool     `yaml:"keywords_whole_word"`
+
+	// RateLimitSeconds allows at most one bell/desktop/command notification per
+	// channel in this many seconds; later messages are counted and reported
+	// with the next notification. Mentions are never delayed. 0 disables.
+	RateLimitSeconds int `yaml:"rate_limit_seconds"`
+
+	// ChannelOverrides holds per-channel settings keyed by channel ID,
+	// channel name (with or without "#") or a glob pattern such as "proj-*"
+	ChannelOverrides map[string]ChannelNotifyConfig `yaml:"channel_overrides"`
+}
+
+// ChannelNotifyConfig overrides notification settings for matching channels.
+// Unset fields fall back to the global settings.
+type ChannelNotifyConfig struct {
+	Muted        bool  `yaml:"muted"`
+	MentionsOnly *bool `yaml:"mentions_only"`
+	Bell         *bool `yaml:"bell"`
+	Desktop      *bool `yaml:"desktop"`
+}
+
+// QuietHoursConfig is a daily window ("HH:MM" local time) during which
+// bell, desktop and command notifications are suppressed. The window may
+// cross midnight.
+type QuietHoursConfig struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+	// Days limits the window to these weekdays ("mon", "tue", ...). A window
+	// crossing midnight belongs to the day it starts. Empty means every day.
+	Days []string `yaml:"days"`
+}
 
-	MuteChannels []string `yaml:"mute_channels"`
-	DND          bool     `yaml:"dnd"`
+// Contains reports whether t falls within the quiet hours window. It returns
+// false when the window is unset or invalid.
+func (q *QuietHoursConfig) Contains(t time.Time) bool {
+	if q == nil {
+		return false
+	}
+	start, err := time.Parse("15:04", q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return false
+	}
+
+	now := t.Hour()*60 + t.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	if from == to {
+		return false
+	}
+	if from < to {
+		return now >= from && now < to && q.onDay(t.Weekday())
+	}
+	// Crosses midnight (e.g. 22:00-08:00)
+	if now >= from {
+		return q.onDay(t.Weekday())
+	}
+	if now < to {
+		return q.onDay((t.Weekday() + 6) % 7)
+	}
+	return false
+}
+
+// onDay reports whether the window applies to a window starting on day
+func (q *QuietHoursConfig) onDay(day time.Weekday) bool {
+	if len(q.Days) == 0 {
+		return true
+	}
+	name := strings.ToLower(day.String()[:3])
+	for _, d := range q.Days {
+		if strings.ToLower(strings.TrimSpace(d)) == name {
+			return true
+		}
+	}
+	return false
 }
 
 // BellConfig configures terminal bell notifications
 type BellConfig struct {
 	Enabled      bool `yaml:"enabled"`
 	MentionsOnly bool `yaml:"mentions_only"`
+
+	// VisualBell flashes the screen instead of beeping
+	VisualBell bool `yaml:"visual_bell"`
+	// Sequence overrides the bytes written to the terminal (takes
+	// precedence over VisualBell)
+	Sequence string `yaml:"sequence"`
 }
 
 // DesktopConfig configures desktop notifications
 type DesktopConfig struct {
 	Enabled      bool `yaml:"enabled"`
 	MentionsOnly bool `yaml:"mentions_only"`
+
+	// IconPath is an image shown in the notification (ignored if missing)
+	IconPath string `yaml:"icon_path"`
+	// SoundCommand is run on mentions, e.g. "afplay ~/ding.wav". It is split
+	// into arguments like the command template, so quotes group words
+	SoundCommand string `yaml:"sound_command"`
+}
+
+// CommandConfig configures a custom command run for notifications
+type CommandConfig struct {
+	Enabled      bool `yaml:"enabled"`
+	MentionsOnly bool `yaml:"mentions_only"`
+
+	// Template is split into arguments (quotes group words) and run without
+	// a shell. {channel}, {user} and {text} are replaced within arguments,
+	// e.g. "say {user} in {channel}"; the values are also set in
+	// SLACK_SHELL_CHANNEL, SLACK_SHELL_USER and SLACK_SHELL_TEXT.
+	Template string `yaml:"template"`
 }
 
 // TitleConfig configures terminal title notifications
@@ -78,14 +193,44 @@ func (c *Config) Merge(other *Config) {
 	if other.MuteChannels != nil {
 		c.MuteChannels = other.MuteChannels
 	}
+	if other.QuietHours != nil {
+		c.QuietHours = other.QuietHours
+	}
+	if other.Keywords != nil {
+		c.Keywords = other.Keywords
+	}
+	c.KeywordsWholeWord = other.KeywordsWholeWord
+	if other.RateLimitSeconds > 0 {
+		c.RateLimitSeconds = other.RateLimitSeconds
+	}
+	if other.ChannelOverrides != nil {
+		c.ChannelOverrides = other.ChannelOverrides
+	}
 
 	// Bell config
 	c.Bell.Enabled = other.Bell.Enabled
 	c.Bell.MentionsOnly = other.Bell.MentionsOnly
+	c.Bell.VisualBell = other.Bell.VisualBell
+	if other.Bell.Sequence != "" {
+		c.Bell.Sequence = other.Bell.Sequence
+	}
 
 	// Desktop config
 	c.Desktop.Enabled = other.Desktop.Enabled
 	c.Desktop.MentionsOnly = other.Desktop.MentionsOnly
+	if other.Desktop.IconPath != "" {
+		c.Desktop.IconPath = other.Desktop.IconPath
+	}
+	if other.Desktop.SoundCommand != "" {
+		c.Desktop.SoundCommand = other.Desktop.SoundCommand
+	}
+
+	// Command config
+	c.Command.Enabled = other.Command.Enabled
+	c.Command.MentionsOnly = other.Command.MentionsOnly
+	if other.Command.Template != "" {
+		c.Command.Template = other.Command.Template
+	}
 
 	// Title config
 	c.Title.Enabled = other.Title.Enabled
@@ -2,20 +2,44 @@ package notification
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"sync"
 
 	"github.com/gen2brain/beeep"
+	"github.com/polidog/slack-shell/internal/platform"
 )
 
 // DesktopNotifier sends desktop notifications
 type DesktopNotifier struct {
 	config *DesktopConfig
+	icon   string
+
+	mu      sync.Mutex
+	waiting *exec.Cmd // notify-send waiting for a click
 }
 
 // NewDesktopNotifier creates a new desktop notifier
 func NewDesktopNotifier(cfg *DesktopConfig) *DesktopNotifier {
 	return &DesktopNotifier{
 		config: cfg,
+		icon:   resolveIcon(cfg.IconPath),
+	}
+}
+
+// resolveIcon expands path and returns "" if the file does not exist
+func resolveIcon(path string) string {
+	if path == "" {
+		return ""
 	}
+	path, err := platform.ExpandPath(path)
+	if err != nil {
+		return ""
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
 }
 
 // Notify sends a desktop notification
@@ -34,14 +58,47 @@ func (d *DesktopNotifier) Notify(msg Message) error {
 
 	// Format body
 	body := fmt.Sprintf("%s: %s", msg.UserName, truncateText(msg.Text, 100))
+	if msg.Coalesced > 1 {
+		body = fmt.Sprintf("%d new messages in %s (latest from %s)", msg.Coalesced, title, msg.UserName)
+	}
+
+	if msg.IsMention {
+		d.playSound()
+	}
+
+	// Open the channel in the Slack app on click where the platform allows it
+	if link := deepLink(msg); link != "" {
+		if ok, err := d.notifyWithLink(title, body, link); ok {
+			return err
+		}
+	}
 
 	// Send notification using beeep
-	return beeep.Notify(title, body, "")
+	return beeep.Notify(title, body, d.icon)
+}
+
+// playSound runs the configured sound command without blocking
+func (d *DesktopNotifier) playSound() {
+	args := splitArgs(d.config.SoundCommand)
+	if len(args) == 0 {
+		return
+	}
+	for i, arg := range args {
+		if expanded, err := platform.ExpandPath(arg); err == nil {
+			args[i] = expanded
+		}
+	}
+
+	go func() {
+		_ = exec.Command(args[0], args[1:]...).Run()
+	}()
 }
 
 // Close cleans up resources
 func (d *DesktopNotifier) Close() {
-	// No cleanup needed
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stopWaiting()
 }
 
 // truncateText truncates text to a maximum length
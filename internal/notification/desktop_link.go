@@ -0,0 +1,117 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// deepLink returns the slack:// URL that opens msg's channel in the Slack
+// app, or "" if the team is unknown
+func deepLink(msg Message) string {
+	if msg.TeamID == "" || msg.ChannelID == "" {
+		return ""
+	}
+	q := url.Values{}
+	q.Set("team", msg.TeamID)
+	q.Set("id", msg.ChannelID)
+	return "slack://channel?" + q.Encode()
+}
+
+// notifyWithLink shows a notification that opens link when clicked. It
+// returns false when no notifier on this platform can attach an action, in
+// which case the caller falls back to a plain notification. An error means
+// the notifier was found but could not be started.
+func (d *DesktopNotifier) notifyWithLink(title, body, link string) (bool, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		// terminal-notifier supports -open; osascript notifications do not
+		path, err := exec.LookPath("terminal-notifier")
+		if err != nil {
+			return false, nil
+		}
+		args := []string{"-title", title, "-message", body, "-open", link}
+		if d.icon != "" {
+			args = append(args, "-appIcon", d.icon)
+		}
+		cmd := exec.Command(path, args...)
+		if err := cmd.Start(); err != nil {
+			return true, fmt.Errorf("terminal-notifier: %w", err)
+		}
+		go func() { _ = cmd.Wait() }()
+		return true, nil
+
+	case "linux", "freebsd", "netbsd", "openbsd":
+		path, err := exec.LookPath("notify-send")
+		if err != nil || !notifySendHasActions(path) {
+			return false, nil
+		}
+		args := []string{"--action=default=Open", "--wait"}
+		if d.icon != "" {
+			args = append(args, "--icon", d.icon)
+		}
+		args = append(args, title, body)
+		return true, d.waitForClick(exec.Command(path, args...), link)
+	}
+	return false, nil
+}
+
+// waitForClick starts cmd, a notify-send --wait that prints the action name
+// when clicked, and opens link if it was. Only the newest notification is
+// waited on: starting one stops the previous notify-send, so at most one
+// process is left running.
+func (d *DesktopNotifier) waitForClick(cmd *exec.Cmd, link string) error {
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("notify-send: %w", err)
+	}
+	d.stopWaiting()
+	d.waiting = cmd
+
+	go func() {
+		err := cmd.Wait()
+
+		d.mu.Lock()
+		if d.waiting == cmd {
+			d.waiting = nil
+		}
+		d.mu.Unlock()
+
+		if err == nil && strings.TrimSpace(out.String()) == "default" {
+			_ = exec.Command("xdg-open", link).Run()
+		}
+	}()
+	return nil
+}
+
+// stopWaiting kills the notify-send still waiting for a click, if any. The
+// caller must hold d.mu.
+func (d *DesktopNotifier) stopWaiting() {
+	if d.waiting != nil {
+		_ = d.waiting.Process.Kill()
+		d.waiting = nil
+	}
+}
+
+var (
+	notifySendActionsOnce sync.Once
+	notifySendActions     bool
+)
+
+// notifySendHasActions reports whether notify-send supports --action. Older
+// versions reject it, so those show a plain notification instead.
+func notifySendHasActions(path string) bool {
+	notifySendActionsOnce.Do(func() {
+		out, err := exec.Command(path, "--help").Output()
+		notifySendActions = err == nil && strings.Contains(string(out), "--action")
+	})
+	return notifySendActions
+}
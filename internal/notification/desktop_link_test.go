@@ -0,0 +1,45 @@
+package notification
+
+import (
+	"os/exec"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWaitForClickStopsPreviousNotification(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sleep")
+	}
+	d := NewDesktopNotifier(&DesktopConfig{})
+	defer d.Close()
+
+	first := exec.Command("sleep", "60")
+	if err := d.waitForClick(first, "slack://channel"); err != nil {
+		t.Fatalf("waitForClick: %v", err)
+	}
+	second := exec.Command("sleep", "60")
+	if err := d.waitForClick(second, "slack://channel"); err != nil {
+		t.Fatalf("waitForClick: %v", err)
+	}
+
+	// Signal fails once the killed process has been reaped
+	deadline := time.Now().Add(2 * time.Second)
+	for first.Process.Signal(syscall.Signal(0)) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("first notify-send still running after a newer notification")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	d.mu.Lock()
+	waiting := d.waiting
+	d.mu.Unlock()
+	if waiting != second {
+		t.Error("newest notification is not the one waiting for a click")
+	}
+
+	if err := d.waitForClick(exec.Command("/nonexistent/notify-send"), "slack://channel"); err == nil {
+		t.Error("expected an error when notify-send cannot start")
+	}
+}
@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"regexp"
+	"strings"
+)
+
+// KeywordMatcher finds configured keywords in message text (case-insensitive)
+type KeywordMatcher struct {
+	re *regexp.Regexp
+}
+
+// NewKeywordMatcher builds a matcher for keywords. With wholeWord set, a
+// keyword only matches when it is not part of a longer word. Returns nil
+// when there are no keywords.
+func NewKeywordMatcher(keywords []string, wholeWord bool) *KeywordMatcher {
+	var parts []string
+	for _, kw := range keywords {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			parts = append(parts, regexp.QuoteMeta(kw))
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+
+	pattern := "(?i)(?:" + strings.Join(parts, "|") + ")"
+	if wholeWord {
+		// \b only knows ASCII word characters, so spell out a boundary that
+		// also works for keywords like "リリース"
+		pattern = `(?i)(?:^|[^\p{L}\p{N}_])(?:` + strings.Join(parts, "|") + `)(?:$|[^\p{L}\p{N}_])`
+	}
+	return &KeywordMatcher{re: regexp.MustCompile(pattern)}
+}
+
+// Match reports whether text contains any keyword. A nil matcher never matches.
+func (k *KeywordMatcher) Match(text string) bool {
+	if k == nil {
+		return false
+	}
+	return k.re.MatchString(text)
+}
@@ -1,8 +1,11 @@
 package notification
 
 import (
+	"path"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 // Manager coordinates all notification systems
@@ -10,11 +13,21 @@ type Manager struct {
 	config  *Config
 	bell    *BellNotifier
 	desktop *DesktopNotifier
+	command *CommandNotifier
 	title   *TitleNotifier
 	visual  *VisualNotifier
 
+	keywords *KeywordMatcher
+
 	unreadCount map[string]int
 	mu          sync.Mutex
+
+	// now returns the current time (replaced in tests)
+	now func() time.Time
+
+	// Rate limiting state per channel
+	lastNotified map[string]time.Time
+	suppressed   map[string]int
 }
 
 // NewManager creates a new notification manager
@@ -24,21 +37,28 @@ func NewManager(cfg *Config) *Manager {
 	}
 
 	m := &Manager{
-		config:      cfg,
-		unreadCount: make(map[string]int),
+		config:       cfg,
+		unreadCount:  make(map[string]int),
+		lastNotified: make(map[string]time.Time),
+		suppressed:   make(map[string]int),
+		now:          time.Now,
 	}
 
 	// Initialize notifiers
 	m.bell = NewBellNotifier(&cfg.Bell)
 	m.desktop = NewDesktopNotifier(&cfg.Desktop)
+	m.command = NewCommandNotifier(&cfg.Command)
 	m.title = NewTitleNotifier(&cfg.Title)
 	m.visual = NewVisualNotifier(&cfg.Visual)
+	m.keywords = NewKeywordMatcher(cfg.Keywords, cfg.KeywordsWholeWord)
 
 	return m
 }
 
-// HandleMessage processes an incoming message and triggers notifications
-func (m *Manager) HandleMessage(msg Message, currentChannelID string, inTailMode bool) {
+// HandleMessage processes an incoming message and triggers notifications.
+// viewedChannels holds the IDs of channels currently on screen; messages
+// for them are not notified.
+func (m *Manager) HandleMessage(msg Message, viewedChannels map[string]bool) {
 	// Check if notifications are enabled
 	if !m.config.Enabled {
 		return
@@ -50,24 +70,66 @@ func (m *Manager) HandleMessage(msg Message, currentChannelID string, inTailMode
 	}
 
 	// Check if channel is muted
-	if m.isChannelMuted(msg.ChannelID, msg.ChannelName) {
+	override := m.channelOverride(msg.ChannelID, msg.ChannelName)
+	if m.isChannelMuted(msg.ChannelID, msg.ChannelName) || (override != nil && override.Muted) {
 		return
 	}
 
-	// Skip if currently viewing this channel (unless in tail mode)
-	if msg.ChannelID == currentChannelID && !inTailMode {
+	// Skip if currently viewing this channel
+	if viewedChannels[msg.ChannelID] {
 		return
 	}
 
+	// Keyword hits count as mentions for mentions_only
+	if !msg.IsMention && m.keywords.Match(msg.Text) {
+		msg.IsMention = true
+	}
+
 	// Increment unread count
 	m.mu.Lock()
 	m.unreadCount[msg.ChannelID]++
 	totalUnread := m.getTotalUnreadLocked()
 	m.mu.Unlock()
 
-	// Check mentions_only for each notifier
-	shouldBell := m.config.Bell.Enabled && (!m.config.Bell.MentionsOnly || msg.IsMention)
-	shouldDesktop := m.config.Desktop.Enabled && (!m.config.Desktop.MentionsOnly || msg.IsMention)
+	// Check mentions_only for each notifier; channel overrides take
+	// precedence
+	bellEnabled, bellMentionsOnly := m.config.Bell.Enabled, m.config.Bell.MentionsOnly
+	desktopEnabled, desktopMentionsOnly := m.config.Desktop.Enabled, m.config.Desktop.MentionsOnly
+	commandMentionsOnly := m.config.Command.MentionsOnly
+	if override != nil {
+		if override.Bell != nil {
+			bellEnabled = *override.Bell
+		}
+		if override.Desktop != nil {
+			desktopEnabled = *override.Desktop
+		}
+		if override.MentionsOnly != nil {
+			bellMentionsOnly = *override.MentionsOnly
+			desktopMentionsOnly = *override.MentionsOnly
+			commandMentionsOnly = *override.MentionsOnly
+		}
+	}
+
+	shouldBell := bellEnabled && (!bellMentionsOnly || msg.IsMention)
+	shouldDesktop := desktopEnabled && (!desktopMentionsOnly || msg.IsMention)
+	shouldCommand := m.config.Command.Enabled && (!commandMentionsOnly || msg.IsMention)
+
+	// Quiet hours silence the bell, desktop and command notifiers; unread
+	// counts, the title and visual notifications are still updated
+	if m.config.QuietHours.Contains(m.now()) {
+		shouldBell = false
+		shouldDesktop = false
+		shouldCommand = false
+	}
+
+	if (shouldBell || shouldDesktop || shouldCommand) && !msg.IsMention {
+		var ok bool
+		if msg.Coalesced, ok = m.rateLimit(msg.ChannelID); !ok {
+			shouldBell = false
+			shouldDesktop = false
+			shouldCommand = false
+		}
+	}
 
 	// Trigger notifications
 	if shouldBell {
@@ -78,6 +140,10 @@ func (m *Manager) HandleMessage(msg Message, currentChannelID string, inTailMode
 		m.desktop.Notify(msg)
 	}
 
+	if shouldCommand {
+		m.command.Notify(msg)
+	}
+
 	if m.config.Title.Enabled {
 		m.title.UpdateUnreadCount(totalUnread)
 	}
@@ -87,6 +153,85 @@ func (m *Manager) HandleMessage(msg Message, currentChannelID string, inTailMode
 	}
 }
 
+// TestResult is the outcome of one notifier in Test
+type TestResult struct {
+	Name    string
+	Enabled bool
+	Err     error
+}
+
+// testTitleDuration is how long Test shows the unread count in the title
+const testTitleDuration = 3 * time.Second
+
+// Test sends msg through every notifier directly, bypassing DND, mutes,
+// quiet hours and rate limiting, and reports how each one did. Disabled
+// notifiers are skipped.
+func (m *Manager) Test(msg Message) []TestResult {
+	results := []TestResult{
+		{Name: "bell", Enabled: m.config.Bell.Enabled},
+		{Name: "desktop", Enabled: m.config.Desktop.Enabled},
+		{Name: "command", Enabled: m.config.Command.Enabled},
+		{Name: "title", Enabled: m.config.Title.Enabled},
+		{Name: "visual", Enabled: m.config.Visual.Enabled},
+	}
+
+	for i := range results {
+		r := &results[i]
+		if !r.Enabled {
+			continue
+		}
+		switch r.Name {
+		case "bell":
+			r.Err = m.bell.Notify(msg)
+		case "desktop":
+			r.Err = m.desktop.Notify(msg)
+		case "command":
+			r.Err = m.command.Notify(msg)
+		case "title":
+			m.title.UpdateUnreadCount(m.GetTotalUnread() + 1)
+			time.AfterFunc(testTitleDuration, func() {
+				m.title.UpdateUnreadCount(m.GetTotalUnread())
+			})
+		case "visual":
+			r.Err = m.visual.Notify(msg)
+		}
+	}
+	return results
+}
+
+// rateLimit reports whether a notification for channelID may fire now and,
+// if so, how many messages it covers. Suppressed messages are counted
+// toward the next allowed notification.
+func (m *Manager) rateLimit(channelID string) (int, bool) {
+	window := time.Duration(m.config.RateLimitSeconds) * time.Second
+	if window <= 0 {
+		return 1, true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if last, ok := m.lastNotified[channelID]; ok && now.Sub(last) < window {
+		m.suppressed[channelID]++
+		return 0, false
+	}
+
+	count := m.suppressed[channelID] + 1
+	delete(m.suppressed, channelID)
+	m.lastNotified[channelID] = now
+	return count, true
+}
+
+// Keywords returns the keyword matcher used for highlighting (nil if no
+// keywords are configured)
+func (m *Manager) Keywords() *KeywordMatcher {
+	if m == nil {
+		return nil
+	}
+	return m.keywords
+}
+
 // ClearUnread clears the unread count for a channel
 func (m *Manager) ClearUnread(channelID string) {
 	m.mu.Lock()
@@ -179,13 +324,46 @@ func (m *Manager) UnmuteChannel(channelID string) {
 
 func (m *Manager) isChannelMuted(channelID, channelName string) bool {
 	for _, ch := range m.config.MuteChannels {
-		if ch == channelID || strings.EqualFold(ch, channelName) {
+		if ch == channelID || strings.EqualFold(strings.TrimPrefix(ch, "#"), channelName) {
 			return true
 		}
 	}
 	return false
 }
 
+// channelOverride returns the override for a channel, or nil. Keys matching
+// the channel ID or name exactly win over glob patterns; among patterns the
+// first in sorted order wins.
+func (m *Manager) channelOverride(channelID, channelName string) *ChannelNotifyConfig {
+	if len(m.config.ChannelOverrides) == 0 {
+		return nil
+	}
+
+	name := strings.ToLower(channelName)
+	keys := make([]string, 0, len(m.config.ChannelOverrides))
+	for key := range m.config.ChannelOverrides {
+		k := strings.ToLower(strings.TrimPrefix(key, "#"))
+		if key == channelID || (name != "" && k == name) {
+			override := m.config.ChannelOverrides[key]
+			return &override
+		}
+		keys = append(keys, key)
+	}
+
+	if name == "" {
+		return nil
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		pattern := strings.ToLower(strings.TrimPrefix(key, "#"))
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			override := m.config.ChannelOverrides[key]
+			return &override
+		}
+	}
+	return nil
+}
+
 // Close cleans up all notifiers
 func (m *Manager) Close() {
 	if m.bell != nil {
@@ -194,6 +372,9 @@ func (m *Manager) Close() {
 	if m.desktop != nil {
 		m.desktop.Close()
 	}
+	if m.command != nil {
+		m.command.Close()
+	}
 	if m.title != nil {
 		m.title.Close()
 	}
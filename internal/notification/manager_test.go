@@ -0,0 +1,121 @@
+package notification
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietHoursContains(t *testing.T) {
+	// 2026-01-05 is a Monday
+	at := func(day int, hhmm string) time.Time {
+		tm, _ := time.Parse("15:04", hhmm)
+		return time.Date(2026, 1, day, tm.Hour(), tm.Minute(), 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name string
+		q    *QuietHoursConfig
+		t    time.Time
+		want bool
+	}{
+		{"unset", nil, at(5, "23:00"), false},
+		{"same day inside", &QuietHoursConfig{Start: "12:00", End: "13:00"}, at(5, "12:30"), true},
+		{"same day end is exclusive", &QuietHoursConfig{Start: "12:00", End: "13:00"}, at(5, "13:00"), false},
+		{"overnight before midnight", &QuietHoursConfig{Start: "22:00", End: "08:00"}, at(5, "23:30"), true},
+		{"overnight after midnight", &QuietHoursConfig{Start: "22:00", End: "08:00"}, at(6, "07:59"), true},
+		{"overnight outside", &QuietHoursConfig{Start: "22:00", End: "08:00"}, at(6, "12:00"), false},
+		{"invalid", &QuietHoursConfig{Start: "late", End: "08:00"}, at(5, "23:30"), false},
+		{"weekday match", &QuietHoursConfig{Start: "22:00", End: "08:00", Days: []string{"Mon"}}, at(5, "23:00"), true},
+		{"weekday miss", &QuietHoursConfig{Start: "22:00", End: "08:00", Days: []string{"tue"}}, at(5, "23:00"), false},
+		// Tuesday 07:00 is still Monday night's window
+		{"overnight belongs to start day", &QuietHoursConfig{Start: "22:00", End: "08:00", Days: []string{"mon"}}, at(6, "07:00"), true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.q.Contains(tt.t); got != tt.want {
+			t.Errorf("%s: Contains(%s) = %v; want %v", tt.name, tt.t.Format("Mon 15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestHandleMessageQuietHours(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Bell.Enabled = false
+	cfg.Desktop.Enabled = false
+	cfg.Title.Enabled = false
+	cfg.Visual.DismissAfter = 0
+	cfg.QuietHours = &QuietHoursConfig{Start: "22:00", End: "08:00"}
+
+	m := NewManager(cfg)
+	msg := Message{ChannelID: "C1", ChannelName: "general", Text: "hi"}
+
+	// Quiet hours only silence the bell, desktop and command notifiers
+	m.now = func() time.Time { return time.Date(2026, 1, 5, 23, 0, 0, 0, time.Local) }
+	m.HandleMessage(msg, nil)
+	if got := m.GetTotalUnread(); got != 1 {
+		t.Errorf("unread during quiet hours = %d; want 1", got)
+	}
+	if got := len(m.GetVisualNotifications()); got != 1 {
+		t.Errorf("visual notifications during quiet hours = %d; want 1", got)
+	}
+
+	m.now = func() time.Time { return time.Date(2026, 1, 6, 9, 0, 0, 0, time.Local) }
+	m.HandleMessage(msg, nil)
+	if got := m.GetTotalUnread(); got != 2 {
+		t.Errorf("unread outside quiet hours = %d; want 2", got)
+	}
+	if got := len(m.GetVisualNotifications()); got != 2 {
+		t.Errorf("visual notifications outside quiet hours = %d; want 2", got)
+	}
+}
+
+func TestHandleMessageViewedChannels(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Bell.Enabled = false
+	cfg.Desktop.Enabled = false
+	cfg.Title.Enabled = false
+	cfg.Visual.DismissAfter = 0
+
+	// Live mode on C1 while peeking at C2
+	viewed := map[string]bool{"C1": true, "C2": true}
+
+	tests := []struct {
+		channelID  string
+		wantUnread int
+	}{
+		{"C1", 0}, // live channel
+		{"C2", 0}, // peek channel
+		{"C3", 1}, // not on screen
+	}
+
+	for _, tt := range tests {
+		m := NewManager(cfg)
+		m.HandleMessage(Message{ChannelID: tt.channelID, ChannelName: "ch", Text: "hi"}, viewed)
+		if got := m.GetUnreadForChannel(tt.channelID); got != tt.wantUnread {
+			t.Errorf("unread for %s = %d; want %d", tt.channelID, got, tt.wantUnread)
+		}
+	}
+}
+
+func TestKeywordMatcherWholeWord(t *testing.T) {
+	m := NewKeywordMatcher([]string{"deploy", "リリース"}, true)
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Deploy is done", true},
+		{"deployment is done", false},
+		{"redeploy now", false},
+		{"(deploy)", true},
+		{"リリース", true},
+		{"明日 リリース します", true},
+		{"リリース!", true},
+		{"リリースノート", false},
+		{"再リリース", false},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.text); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
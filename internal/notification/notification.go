@@ -2,12 +2,17 @@ package notification
 
 // Message represents a notification message
 type Message struct {
+	TeamID      string // Used for the slack:// deep link in desktop notifications
 	ChannelID   string
 	ChannelName string
 	UserName    string
 	Text        string
 	IsMention   bool
 	IsIM        bool
+
+	// Coalesced is the number of messages this notification stands for when
+	// rate limiting merged several into one (0 or 1 for a single message)
+	Coalesced int
 }
 
 // Notifier interface for notification implementations
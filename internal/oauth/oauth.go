@@ -10,16 +10,18 @@ import (
 	"crypto/x509/pkix"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"net"
 	"net/http"
 	"net/url"
-	"os/exec"
-	"runtime"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/polidog/slack-shell/internal/config"
+	"github.com/polidog/slack-shell/internal/platform"
 )
 
 const (
@@ -41,6 +43,13 @@ var requiredUserScopes = []string{
 	"users:read",
 	"chat:write",
 	"team:read",
+	"pins:read",
+	"pins:write",
+	"users.profile:read",
+	"users.profile:write",
+	"users:write",
+	"dnd:read",
+	"dnd:write",
 }
 
 // Required scopes for bot token
@@ -50,10 +59,32 @@ var requiredBotScopes = []string{
 	"chat:write",
 }
 
+// scopeFeatures maps user scopes to the shell features that stop working
+// without them. Used to explain missing scopes after login.
+var scopeFeatures = map[string]string{
+	"channels:read":       "ls, cd",
+	"channels:history":    "cat, browse, live",
+	"groups:read":         "ls (private channels)",
+	"groups:history":      "cat (private channels)",
+	"im:read":             "ls dm, cd @user",
+	"im:history":          "cat (DMs)",
+	"users:read":          "user name display",
+	"chat:write":          "send, live message sending",
+	"pins:read":           "pins",
+	"pins:write":          "pin, unpin",
+	"users.profile:read":  "status (showing the current status)",
+	"users.profile:write": "status",
+	"users:write":         "status away/auto",
+	"dnd:read":            "dnd slack",
+	"dnd:write":           "dnd slack <minutes>, dnd slack off",
+}
+
 type OAuthFlow struct {
 	clientID     string
 	clientSecret string
 	redirectPort int
+	userScopes   []string
+	botScopes    []string
 	state        string
 	server       *http.Server
 	resultChan   chan *OAuthResult
@@ -88,10 +119,24 @@ func NewOAuthFlow(cfg *config.Config) (*OAuthFlow, error) {
 		return nil, fmt.Errorf("failed to generate state: %w", err)
 	}
 
+	// Blank entries are dropped; an override with no scopes left falls
+	// back to the defaults
+	userScopes := requiredUserScopes
+	if scopes := ParseScopes(strings.Join(cfg.UserScopes, ",")); len(scopes) > 0 {
+		userScopes = scopes
+		warnReducedScopes(userScopes)
+	}
+	botScopes := requiredBotScopes
+	if scopes := ParseScopes(strings.Join(cfg.BotScopes, ",")); len(scopes) > 0 {
+		botScopes = scopes
+	}
+
 	return &OAuthFlow{
 		clientID:     cfg.ClientID,
 		clientSecret: cfg.ClientSecret,
 		redirectPort: cfg.RedirectPort,
+		userScopes:   userScopes,
+		botScopes:    botScopes,
 		state:        state,
 		resultChan:   make(chan *OAuthResult, 1),
 	}, nil
@@ -109,30 +154,33 @@ func (o *OAuthFlow) Start() (*config.Credentials, error) {
 	mux.HandleFunc("/callback", o.handleCallback)
 	mux.HandleFunc("/", o.handleRoot)
 
+	// Bind the configured port, falling back to a free port if it is taken
+	listener, err := o.listen()
+	if err != nil {
+		return nil, err
+	}
+
 	o.server = &http.Server{
-		Addr:      fmt.Sprintf(":%d", o.redirectPort),
 		Handler:   mux,
 		TLSConfig: tlsConfig,
 	}
 
 	go func() {
-		// ListenAndServeTLS with empty cert/key paths uses TLSConfig
-		if err := o.server.ListenAndServeTLS("", ""); err != http.ErrServerClosed {
+		// ServeTLS with empty cert/key paths uses TLSConfig
+		if err := o.server.ServeTLS(listener, "", ""); err != http.ErrServerClosed {
 			o.resultChan <- &OAuthResult{Error: err}
 		}
 	}()
 
-	// Wait a moment for the server to start
-	time.Sleep(100 * time.Millisecond)
-
 	// Open browser
 	authURL := o.buildAuthURL()
+	fmt.Printf("\nコールバックURL: %s\n", o.redirectURI())
 	fmt.Printf("\n認証のためブラウザを開いています...\n")
 	fmt.Printf("自動で開かない場合は以下のURLにアクセスしてください:\n%s\n\n", authURL)
 	fmt.Printf("⚠️  ブラウザで「この接続は安全ではありません」と表示された場合:\n")
 	fmt.Printf("   「詳細設定」→「localhostにアクセスする」をクリックしてください\n\n")
 
-	if err := openBrowser(authURL); err != nil {
+	if err := platform.OpenBrowser(authURL); err != nil {
 		fmt.Printf("ブラウザを開けませんでした: %v\n", err)
 	}
 
@@ -151,12 +199,53 @@ func (o *OAuthFlow) Start() (*config.Credentials, error) {
 	return result.Credentials, nil
 }
 
+// listen binds the callback listener on the configured redirect port.
+// If the port is already in use, an OS-assigned free port is used instead
+// and redirectPort is updated so the redirect URI matches.
+func (o *OAuthFlow) listen() (net.Listener, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", o.redirectPort))
+	if err == nil {
+		return listener, nil
+	}
+	if !isAddrInUse(err) {
+		return nil, fmt.Errorf("failed to listen on port %d: %w", o.redirectPort, err)
+	}
+
+	listener, err = net.Listen("tcp", ":0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on a free port: %w", err)
+	}
+
+	configuredPort := o.redirectPort
+	o.redirectPort = listener.Addr().(*net.TCPAddr).Port
+	fmt.Printf("⚠️  ポート %d は使用中のため、ポート %d を使用します\n", configuredPort, o.redirectPort)
+	fmt.Printf("   Slack アプリの Redirect URLs に %s を追加してください\n", o.redirectURI())
+
+	return listener, nil
+}
+
+// isAddrInUse reports whether err is an "address already in use" error
+func isAddrInUse(err error) bool {
+	if errors.Is(err, syscall.EADDRINUSE) {
+		return true
+	}
+	// Windows reports WSAEADDRINUSE, which does not match syscall.EADDRINUSE
+	msg := err.Error()
+	return strings.Contains(msg, "address already in use") ||
+		strings.Contains(msg, "Only one usage of each socket address")
+}
+
+// redirectURI returns the OAuth callback URL for the current redirect port
+func (o *OAuthFlow) redirectURI() string {
+	return fmt.Sprintf("https://localhost:%d/callback", o.redirectPort)
+}
+
 func (o *OAuthFlow) buildAuthURL() string {
 	params := url.Values{}
 	params.Set("client_id", o.clientID)
-	params.Set("user_scope", strings.Join(requiredUserScopes, ","))
-	params.Set("scope", strings.Join(requiredBotScopes, ","))
-	params.Set("redirect_uri", fmt.Sprintf("https://localhost:%d/callback", o.redirectPort))
+	params.Set("user_scope", strings.Join(o.userScopes, ","))
+	params.Set("scope", strings.Join(o.botScopes, ","))
+	params.Set("redirect_uri", o.redirectURI())
 	params.Set("state", o.state)
 
 	return fmt.Sprintf("%s?%s", slackAuthorizeURL, params.Encode())
@@ -239,7 +328,7 @@ func (o *OAuthFlow) exchangeCodeForToken(code string) (*config.Credentials, erro
 	data.Set("client_id", o.clientID)
 	data.Set("client_secret", o.clientSecret)
 	data.Set("code", code)
-	data.Set("redirect_uri", fmt.Sprintf("https://localhost:%d/callback", o.redirectPort))
+	data.Set("redirect_uri", o.redirectURI())
 
 	resp, err := http.PostForm(slackTokenURL, data)
 	if err != nil {
@@ -270,6 +359,22 @@ func (o *OAuthFlow) exchangeCodeForToken(code string) (*config.Credentials, erro
 		accessToken = botToken
 	}
 
+	// Warn about scopes that features depend on but were not granted,
+	// whatever scopes were requested
+	if missing := MissingScopes(userScope, requiredUserScopes); len(missing) > 0 {
+		fmt.Printf("⚠️  以下のスコープが付与されていません: %s\n", strings.Join(missing, ", "))
+		for _, scope := range missing {
+			if feature, ok := scopeFeatures[scope]; ok {
+				fmt.Printf("   %s がないため利用できません: %s\n", scope, feature)
+			}
+		}
+	}
+	if botToken != "" {
+		if missing := MissingScopes(botScope, requiredBotScopes); len(missing) > 0 {
+			fmt.Printf("⚠️  ボットトークンに以下のスコープが付与されていません: %s (sudo app が使えない場合があります)\n", strings.Join(missing, ", "))
+		}
+	}
+
 	return &config.Credentials{
 		AccessToken: accessToken,
 		BotToken:    botToken,
@@ -282,6 +387,55 @@ func (o *OAuthFlow) exchangeCodeForToken(code string) (*config.Credentials, erro
 	}, nil
 }
 
+// MissingScopes returns the scopes in required that are not present in the
+// comma-separated granted scope string
+func MissingScopes(granted string, required []string) []string {
+	grantedSet := make(map[string]bool)
+	for _, scope := range strings.Split(granted, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			grantedSet[scope] = true
+		}
+	}
+
+	var missing []string
+	for _, scope := range required {
+		if !grantedSet[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// warnReducedScopes prints the features that will not work because the
+// configured user scopes leave out scopes they rely on. Login still proceeds.
+func warnReducedScopes(userScopes []string) {
+	missing := MissingScopes(strings.Join(userScopes, ","), requiredUserScopes)
+	var lines []string
+	for _, scope := range missing {
+		if feature, ok := scopeFeatures[scope]; ok {
+			lines = append(lines, fmt.Sprintf("   %s がないため利用できません: %s", scope, feature))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Println("⚠️  設定されたスコープでは一部の機能が使えません:")
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+}
+
+// ParseScopes splits a comma-separated scope list, dropping empty entries
+func ParseScopes(s string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(s, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
 func generateState() (string, error) {
 	bytes := make([]byte, 16)
 	if _, err := rand.Read(bytes); err != nil {
@@ -334,20 +488,3 @@ func generateTLSConfig() (*tls.Config, error) {
 		Certificates: []tls.Certificate{cert},
 	}, nil
 }
-
-func openBrowser(url string) error {
-	var cmd *exec.Cmd
-
-	switch runtime.GOOS {
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-	default:
-		return fmt.Errorf("unsupported platform")
-	}
-
-	return cmd.Start()
-}
@@ -0,0 +1,36 @@
+package platform
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// ExpandPath expands environment variables ($VAR and ${VAR}) and a leading
+// ~ (your home directory) or ~user (that user's, if it can be looked up) in
+// a user-supplied path. Unset variables expand to "".
+func ExpandPath(path string) (string, error) {
+	path = os.ExpandEnv(path)
+	if !strings.HasPrefix(path, "~") {
+		return path, nil
+	}
+
+	name, rest := path[1:], ""
+	if i := strings.IndexAny(name, "/"+string(filepath.Separator)); i >= 0 {
+		name, rest = name[:i], name[i+1:]
+	}
+	if name == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, rest), nil
+	}
+
+	u, err := user.Lookup(name)
+	if err != nil || u.HomeDir == "" {
+		return path, nil
+	}
+	return filepath.Join(u.HomeDir, rest), nil
+}
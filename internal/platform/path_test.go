@@ -0,0 +1,56 @@
+package platform
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("SLACK_SHELL_TEST_DIR", "/srv/slack")
+
+	tests := []struct {
+		path, want string
+	}{
+		{"~", home},
+		{"~/", home},
+		{"~/.slackshellrc", filepath.Join(home, ".slackshellrc")},
+		{"$HOME/config.yaml", filepath.Join(home, "config.yaml")},
+		{"${SLACK_SHELL_TEST_DIR}/config.yaml", "/srv/slack/config.yaml"},
+		{"$SLACK_SHELL_TEST_DIR/a/b", "/srv/slack/a/b"},
+		{"/etc/slack-shell.yaml", "/etc/slack-shell.yaml"},
+		{"relative/config.yaml", "relative/config.yaml"},
+		{"~no-such-user-xyz/config.yaml", "~no-such-user-xyz/config.yaml"},
+	}
+	for _, tt := range tests {
+		got, err := ExpandPath(tt.path)
+		if err != nil {
+			t.Errorf("ExpandPath(%q) error: %v", tt.path, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ExpandPath(%q) = %q; want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestExpandPathUser(t *testing.T) {
+	u, err := user.Current()
+	if err != nil || u.HomeDir == "" || u.Username == "" {
+		t.Skip("current user unavailable")
+	}
+	if _, err := os.Stat(u.HomeDir); err != nil {
+		t.Skip("home directory unavailable")
+	}
+
+	got, err := ExpandPath("~" + u.Username + "/config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(u.HomeDir, "config.yaml"); got != want {
+		t.Errorf("ExpandPath(~%s/config.yaml) = %q; want %q", u.Username, got, want)
+	}
+}
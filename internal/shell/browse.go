@@ -7,26 +7,12 @@ import (
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
+	"github.com/polidog/slack-shell/internal/config"
+	"github.com/polidog/slack-shell/internal/keymap"
+	"github.com/polidog/slack-shell/internal/notification"
 	"github.com/polidog/slack-shell/internal/slack"
 )
 
-var (
-	browseSelectedStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("4")).
-				Foreground(lipgloss.Color("15"))
-	browseNormalStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("252"))
-	browseThreadStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("6")).
-				PaddingLeft(2)
-	browseHeaderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("3")).
-				Bold(true)
-	browseHelpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("8"))
-)
-
 // BrowseModel represents the browse mode UI
 type BrowseModel struct {
 	client        *slack.Client
@@ -35,6 +21,14 @@ type BrowseModel struct {
 	scrollOffset  int
 	width, height int
 	userCache     map[string]string
+	keymap        *keymap.Keymap
+	displayConfig *config.DisplayConfig
+	showReactions bool
+	keywords      *notification.KeywordMatcher
+	selfName      string // highlighted as @selfName in messages
+
+	// Polling refresh interval (0 = disabled)
+	refreshInterval time.Duration
 
 	// Thread display
 	threadMessages []slack.Message
@@ -51,30 +45,61 @@ type BrowseModel struct {
 	// Loading state
 	loading    bool
 	loadingErr error
+
+	// Message search
+	messageSearch
+
+	// Permalink of the selected message, shown after pressing Y
+	permalink string
 }
 
 // NewBrowseModel creates a new BrowseModel
-func NewBrowseModel(client *slack.Client, channelID, channelName string, userCache map[string]string) *BrowseModel {
+func NewBrowseModel(client *slack.Client, channelID, channelName string, userCache map[string]string, displayConfig *config.DisplayConfig, km *keymap.Keymap) *BrowseModel {
 	ti := textinput.New()
 	ti.Placeholder = "Type your reply..."
 	ti.CharLimit = 1000
 	ti.Width = 60
 
+	if km == nil {
+		km = keymap.New(nil)
+	}
+	if displayConfig == nil {
+		displayConfig = config.DefaultDisplayConfig()
+	}
+
 	return &BrowseModel{
-		client:      client,
-		channelID:   channelID,
-		channelName: channelName,
-		userCache:   userCache,
-		replyText:   ti,
-		loading:     true,
+		client:          client,
+		channelID:       channelID,
+		channelName:     channelName,
+		userCache:       userCache,
+		keymap:          km,
+		displayConfig:   displayConfig,
+		showReactions:   displayConfig.ReactionsVisible(),
+		refreshInterval: time.Duration(displayConfig.BrowseRefreshSeconds) * time.Second,
+		replyText:       ti,
+		loading:         true,
 	}
 }
 
 // Init initializes the browse model
 func (m *BrowseModel) Init() tea.Cmd {
+	if m.refreshInterval > 0 {
+		return tea.Batch(m.loadMessages(), m.scheduleRefresh())
+	}
 	return m.loadMessages()
 }
 
+// BrowseRefreshTickMsg triggers a periodic reload of browse mode messages
+type BrowseRefreshTickMsg struct {
+	owner *BrowseModel
+}
+
+func (m *BrowseModel) scheduleRefresh() tea.Cmd {
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return BrowseRefreshTickMsg{owner: m}
+	})
+}
+
 // MessagesLoadedMsg is sent when messages are loaded
 type MessagesLoadedMsg struct {
 	Messages []slack.Message
@@ -92,6 +117,12 @@ type ReplySentMsg struct {
 	Err error
 }
 
+// PermalinkMsg is sent when a message's permalink is loaded
+type PermalinkMsg struct {
+	URL string
+	Err error
+}
+
 func (m *BrowseModel) loadMessages() tea.Cmd {
 	return func() tea.Msg {
 		messages, err := m.client.GetMessages(m.channelID, 50)
@@ -113,6 +144,13 @@ func (m *BrowseModel) sendReply(threadTS, text string) tea.Cmd {
 	}
 }
 
+func (m *BrowseModel) loadPermalink(timestamp string) tea.Cmd {
+	return func() tea.Msg {
+		url, err := m.client.GetPermalink(m.channelID, timestamp)
+		return PermalinkMsg{URL: url, Err: err}
+	}
+}
+
 // Update handles messages
 func (m *BrowseModel) Update(msg tea.Msg) (*BrowseModel, tea.Cmd) {
 	var cmd tea.Cmd
@@ -123,14 +161,34 @@ func (m *BrowseModel) Update(msg tea.Msg) (*BrowseModel, tea.Cmd) {
 		if msg.Err != nil {
 			m.loadingErr = msg.Err
 		} else {
+			// On refresh, keep the selected message unless the newest was selected
+			selectedTS := ""
+			if m.selectedIndex >= 0 && m.selectedIndex < len(m.messages)-1 {
+				selectedTS = m.messages[m.selectedIndex].Timestamp
+			}
+
 			m.messages = msg.Messages
 			// Select the last (newest) message by default
 			if len(m.messages) > 0 {
 				m.selectedIndex = len(m.messages) - 1
 			}
+			for i, message := range m.messages {
+				if selectedTS != "" && message.Timestamp == selectedTS {
+					m.selectedIndex = i
+					break
+				}
+			}
+			m.ensureVisible()
 		}
 		return m, nil
 
+	case BrowseRefreshTickMsg:
+		// Ignore ticks from a previous browse session
+		if msg.owner != m {
+			return m, nil
+		}
+		return m, tea.Batch(m.loadMessages(), m.scheduleRefresh())
+
 	case ThreadLoadedMsg:
 		if msg.Err != nil {
 			m.loadingErr = msg.Err
@@ -141,6 +199,14 @@ func (m *BrowseModel) Update(msg tea.Msg) (*BrowseModel, tea.Cmd) {
 		}
 		return m, nil
 
+	case PermalinkMsg:
+		if msg.Err != nil {
+			m.permalink = fmt.Sprintf("Could not get link: %v", msg.Err)
+		} else {
+			m.permalink = msg.URL
+		}
+		return m, nil
+
 	case ReplySentMsg:
 		if msg.Err != nil {
 			m.loadingErr = msg.Err
@@ -180,15 +246,21 @@ func (m *BrowseModel) Update(msg tea.Msg) (*BrowseModel, tea.Cmd) {
 			}
 		}
 
+		// Handle search query input
+		if m.searchMode {
+			m.selectMessage(m.handleSearchKey(msg, m.messages, m.userCache))
+			return m, nil
+		}
+
 		// Handle thread view
 		if m.threadVisible {
-			switch msg.String() {
-			case "q", "esc":
+			switch {
+			case m.keymap.MatchKey(msg, keymap.ActionCloseThread):
 				m.threadVisible = false
 				m.threadMessages = nil
 				m.threadTS = ""
 				return m, nil
-			case "r":
+			case m.keymap.MatchKey(msg, keymap.ActionReply):
 				if m.threadTS != "" {
 					m.inputMode = true
 					m.replyText.Focus()
@@ -199,24 +271,72 @@ func (m *BrowseModel) Update(msg tea.Msg) (*BrowseModel, tea.Cmd) {
 			return m, nil
 		}
 
-		// Handle main list view
-		switch msg.String() {
-		case "q":
+		// Cycle search matches while a query is active
+		if m.searchQuery != "" {
+			switch {
+			case m.keymap.MatchKey(msg, keymap.ActionNextMatch):
+				m.selectMessage(m.jumpToMatch(-1, m.selectedIndex, m.messages, m.userCache))
+				return m, nil
+			case m.keymap.MatchKey(msg, keymap.ActionPrevMatch):
+				m.selectMessage(m.jumpToMatch(1, m.selectedIndex, m.messages, m.userCache))
+				return m, nil
+			case m.keymap.MatchKey(msg, keymap.ActionCancel):
+				m.clearSearch()
+				return m, nil
+			}
+		}
+
+		// Handle main list view. A shown link is dismissed by the next key.
+		m.permalink = ""
+		switch {
+		case m.keymap.MatchKey(msg, keymap.ActionQuit):
 			// Signal to exit browse mode (handled by parent)
 			return m, nil
-		case "up", "k":
+		case m.keymap.MatchKey(msg, keymap.ActionPermalink):
+			// Show the selected message's permalink to copy
+			if len(m.messages) > 0 && m.selectedIndex < len(m.messages) {
+				m.permalink = "Loading link..."
+				return m, m.loadPermalink(m.messages[m.selectedIndex].Timestamp)
+			}
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionSearch):
+			m.startSearch()
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionUp):
 			if m.selectedIndex > 0 {
 				m.selectedIndex--
 				m.ensureVisible()
 			}
 			return m, nil
-		case "down", "j":
+		case m.keymap.MatchKey(msg, keymap.ActionDown):
 			if m.selectedIndex < len(m.messages)-1 {
 				m.selectedIndex++
 				m.ensureVisible()
 			}
 			return m, nil
-		case "enter":
+		case m.keymap.MatchKey(msg, keymap.ActionTop):
+			m.selectedIndex = 0
+			m.scrollOffset = 0
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionBottom):
+			if len(m.messages) > 0 {
+				m.selectedIndex = len(m.messages) - 1
+				m.ensureVisible()
+			}
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionHalfUp):
+			m.moveSelection(-m.halfPage())
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionHalfDown):
+			m.moveSelection(m.halfPage())
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionPageUp):
+			m.moveSelection(-m.getVisibleLines())
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionPageDown):
+			m.moveSelection(m.getVisibleLines())
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionOpenThread):
 			if len(m.messages) > 0 && m.selectedIndex < len(m.messages) {
 				selectedMsg := m.messages[m.selectedIndex]
 				// Use the message timestamp as thread_ts
@@ -228,7 +348,7 @@ func (m *BrowseModel) Update(msg tea.Msg) (*BrowseModel, tea.Cmd) {
 				return m, m.loadThread(threadTS)
 			}
 			return m, nil
-		case "r":
+		case m.keymap.MatchKey(msg, keymap.ActionReply):
 			// Reply to selected message directly (create thread or reply in existing thread)
 			if len(m.messages) > 0 && m.selectedIndex < len(m.messages) {
 				selectedMsg := m.messages[m.selectedIndex]
@@ -248,13 +368,66 @@ func (m *BrowseModel) Update(msg tea.Msg) (*BrowseModel, tea.Cmd) {
 	return m, nil
 }
 
+// selectMessage selects the message at index, if it isn't -1
+func (m *BrowseModel) selectMessage(index int) {
+	if index < 0 {
+		return
+	}
+	m.selectedIndex = index
+	m.ensureVisible()
+}
+
 func (m *BrowseModel) ensureVisible() {
 	visibleLines := m.getVisibleLines()
 	if m.selectedIndex < m.scrollOffset {
 		m.scrollOffset = m.selectedIndex
-	} else if m.selectedIndex >= m.scrollOffset+visibleLines {
-		m.scrollOffset = m.selectedIndex - visibleLines + 1
+		return
 	}
+	// Scroll down until the selected message fits entirely
+	for m.scrollOffset < m.selectedIndex && m.getTotalLinesInRange(m.scrollOffset, m.selectedIndex+1) > visibleLines {
+		m.scrollOffset++
+	}
+}
+
+// getMessageLineCount returns the number of lines a message takes in the list
+func (m *BrowseModel) getMessageLineCount(msgIndex int) int {
+	if msgIndex < 0 || msgIndex >= len(m.messages) {
+		return 1
+	}
+	return len(m.formatMessageLines(m.messages[msgIndex], msgIndex))
+}
+
+// getTotalLinesInRange returns total lines for messages from startIdx to endIdx (exclusive)
+func (m *BrowseModel) getTotalLinesInRange(startIdx, endIdx int) int {
+	total := 0
+	for i := startIdx; i < endIdx && i < len(m.messages); i++ {
+		total += m.getMessageLineCount(i)
+	}
+	return total
+}
+
+// halfPage returns the number of messages to move for a half-page scroll
+func (m *BrowseModel) halfPage() int {
+	half := m.getVisibleLines() / 2
+	if half < 1 {
+		return 1
+	}
+	return half
+}
+
+// moveSelection moves the selected message by delta, clamped to the list
+func (m *BrowseModel) moveSelection(delta int) {
+	if len(m.messages) == 0 {
+		return
+	}
+	m.selectedIndex += delta
+	if m.selectedIndex < 0 {
+		m.selectedIndex = 0
+	}
+	if m.selectedIndex > len(m.messages)-1 {
+		m.selectedIndex = len(m.messages) - 1
+	}
+	m.ensureVisible()
 }
 
 func (m *BrowseModel) getVisibleLines() int {
@@ -312,6 +485,19 @@ func (m *BrowseModel) View() string {
 		sb.WriteString("\n")
 	}
 
+	// Search bar
+	if m.searchMode || m.searchQuery != "" {
+		sb.WriteString("\n")
+		sb.WriteString(m.renderSearchBar())
+	}
+
+	// Permalink of the selected message
+	if m.permalink != "" {
+		sb.WriteString("\n")
+		sb.WriteString(browseNormalStyle.Render("Link: " + m.permalink))
+		sb.WriteString("\n")
+	}
+
 	sb.WriteString(m.renderHelp())
 
 	return sb.String()
@@ -321,25 +507,36 @@ func (m *BrowseModel) renderMessageList() string {
 	var sb strings.Builder
 
 	visibleLines := m.getVisibleLines()
-	endIdx := m.scrollOffset + visibleLines
-	if endIdx > len(m.messages) {
-		endIdx = len(m.messages)
+	usedLines := 0
+	endIdx := m.scrollOffset
+
+	// Highlight the search query first, then mentions of you
+	var terms []highlightTerm
+	if m.searchQuery != "" {
+		terms = append(terms, newHighlightTerm(m.searchQuery, browseSearchMatchStyle.Bold(true), false))
 	}
+	terms = append(terms, mentionTerms(m.selfName)...)
 
-	for i := m.scrollOffset; i < endIdx; i++ {
-		msg := m.messages[i]
-		line := m.formatMessageLine(msg, i)
+	for i := m.scrollOffset; i < len(m.messages); i++ {
+		lines := m.formatMessageLines(m.messages[i], i)
+		if usedLines+len(lines) > visibleLines && i > m.scrollOffset {
+			break
+		}
+		usedLines += len(lines)
+		endIdx = i + 1
 
-		if i == m.selectedIndex {
-			sb.WriteString(browseSelectedStyle.Render(line))
-		} else {
-			sb.WriteString(browseNormalStyle.Render(line))
+		for _, line := range lines {
+			style := browseNormalStyle
+			if i == m.selectedIndex {
+				style = browseSelectedStyle
+			}
+			sb.WriteString(renderHighlighted(line, style, terms))
+			sb.WriteString("\n")
 		}
-		sb.WriteString("\n")
 	}
 
 	// Scroll indicator
-	if len(m.messages) > visibleLines {
+	if m.scrollOffset > 0 || endIdx < len(m.messages) {
 		sb.WriteString(fmt.Sprintf("\n[%d-%d of %d messages]",
 			m.scrollOffset+1, endIdx, len(m.messages)))
 	}
@@ -357,20 +554,40 @@ func (m *BrowseModel) renderThread() string {
 
 	sb.WriteString("\n")
 	for i, msg := range m.threadMessages {
-		line := m.formatMessageLine(msg, i)
-		if i == 0 {
-			// Parent message
-			sb.WriteString(browseNormalStyle.Render(line))
-		} else {
-			// Thread replies
-			sb.WriteString(browseThreadStyle.Render("  " + line))
+		for _, line := range m.formatMessageLines(msg, i) {
+			if i == 0 {
+				// Parent message
+				sb.WriteString(browseNormalStyle.Render(line))
+			} else {
+				// Thread replies
+				sb.WriteString(browseThreadStyle.Render("  " + line))
+			}
+			sb.WriteString("\n")
 		}
-		sb.WriteString("\n")
 	}
 
 	return sb.String()
 }
 
+// formatMessageLines returns the message line followed by its attachment
+// lines and, when enabled, a reactions line
+func (m *BrowseModel) formatMessageLines(msg slack.Message, index int) []string {
+	lines := []string{m.formatMessageLine(msg, index)}
+	for _, att := range msg.Attachments {
+		if att.Title != "" {
+			lines = append(lines, "    📎 "+truncatePreview(ConvertEmoji(att.Title), m.width-10))
+		}
+		if att.Text != "" {
+			attText := strings.ReplaceAll(ConvertEmoji(ResolveMentions(att.Text, m.userCache)), "\n", " ")
+			lines = append(lines, "       "+truncatePreview(attText, m.width-30))
+		}
+	}
+	if m.showReactions && len(msg.Reactions) > 0 {
+		lines = append(lines, "    "+formatReactions(msg.Reactions))
+	}
+	return lines
+}
+
 func (m *BrowseModel) formatMessageLine(msg slack.Message, index int) string {
 	// Get user name
 	userName := msg.UserName
@@ -389,7 +606,7 @@ func (m *BrowseModel) formatMessageLine(msg slack.Message, index int) string {
 
 	// Parse timestamp
 	ts := m.parseTimestamp(msg.Timestamp)
-	timeStr := ts.Format("01/02 15:04")
+	timeStr := m.displayConfig.FormatTime(ts, "01/02 15:04")
 
 	// Thread indicator
 	threadIndicator := ""
@@ -413,7 +630,7 @@ func (m *BrowseModel) formatMessageLine(msg slack.Message, index int) string {
 	// Replace newlines with spaces
 	text = strings.ReplaceAll(text, "\n", " ")
 
-	return fmt.Sprintf("[%s] %s: %s%s", timeStr, userName, text, threadIndicator)
+	return fmt.Sprintf("%s[%s] %s: %s%s", keywordMarker(m.keywords, msg.Text), timeStr, userName, text, threadIndicator)
 }
 
 func (m *BrowseModel) parseTimestamp(ts string) time.Time {
@@ -427,14 +644,22 @@ func (m *BrowseModel) parseTimestamp(ts string) time.Time {
 	return time.Unix(sec, 0)
 }
 
+func (m *BrowseModel) renderSearchBar() string {
+	return m.messageSearch.renderSearchBar(browseSearchBarStyle)
+}
+
 func (m *BrowseModel) renderHelp() string {
 	var help string
 	if m.inputMode {
 		help = "Enter: send | Esc: cancel"
+	} else if m.searchMode {
+		help = "Type to search | Enter: confirm | Esc: cancel"
 	} else if m.threadVisible {
 		help = "r: reply | q/Esc: back | j/k: scroll"
+	} else if m.searchQuery != "" {
+		help = "n: older match | N: newer match | Esc: clear search | Enter: thread | j/k: nav | q: exit"
 	} else {
-		help = "Enter: view thread | r: reply | j/k/arrows: navigate | q: exit"
+		help = "Enter: view thread | r: reply | Y: link | /: search | j/k/arrows: navigate | g/G: top/bottom | q: exit"
 	}
 	return "\n" + browseHelpStyle.Render(help)
 }
@@ -470,6 +695,34 @@ func (m *BrowseModel) RemoveDeletedMessage(channelID, deletedTimestamp string) {
 	}
 }
 
+// UpdateEditedMessage replaces the text of a message edited via realtime events
+func (m *BrowseModel) UpdateEditedMessage(channelID, timestamp, text string) {
+	// Only update if it's for this channel
+	if channelID != m.channelID {
+		return
+	}
+	if updateMessageText(m.messages, timestamp, text) {
+		return
+	}
+	if m.threadVisible {
+		updateMessageText(m.threadMessages, timestamp, text)
+	}
+}
+
+// ApplyReaction updates reaction counts for a message in this channel
+func (m *BrowseModel) ApplyReaction(evt slack.ReactionEvent) {
+	if evt.ChannelID != m.channelID {
+		return
+	}
+	if applyReaction(m.messages, evt) {
+		m.ensureVisible()
+		return
+	}
+	if m.threadVisible {
+		applyReaction(m.threadMessages, evt)
+	}
+}
+
 // AddIncomingMessage adds a new message from realtime events
 func (m *BrowseModel) AddIncomingMessage(channelID, userID, userName, text, timestamp, threadTS string) {
 	// Only add if it's for this channel
@@ -510,11 +763,11 @@ func (m *BrowseModel) GetChannelID() string {
 
 // ShouldExit returns true if the user wants to exit browse mode
 func (m *BrowseModel) ShouldExit(msg tea.KeyMsg) bool {
-	// Only exit on 'q' when not in input mode and not in thread view
-	if m.inputMode || m.threadVisible {
+	// Only exit on 'q' when not in input mode, thread view or search input
+	if m.inputMode || m.threadVisible || m.searchMode {
 		return false
 	}
-	return msg.String() == "q"
+	return m.keymap.MatchKey(msg, keymap.ActionQuit)
 }
 
 // IsInInputMode returns true if browse model is in input mode
@@ -0,0 +1,48 @@
+package shell
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// errNoClipboard is returned when no clipboard tool is installed
+var errNoClipboard = errors.New("no clipboard available")
+
+// clipboardCommand returns the command that copies its stdin to the system
+// clipboard, or nil if none is available
+func clipboardCommand() *exec.Cmd {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		candidates = append(candidates,
+			[]string{"xclip", "-selection", "clipboard"},
+			[]string{"xsel", "--clipboard", "--input"},
+		)
+	}
+	for _, c := range candidates {
+		if path, err := exec.LookPath(c[0]); err == nil {
+			return exec.Command(path, c[1:]...)
+		}
+	}
+	return nil
+}
+
+// copyToClipboard copies text to the system clipboard
+func copyToClipboard(text string) error {
+	cmd := clipboardCommand()
+	if cmd == nil {
+		return errNoClipboard
+	}
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
@@ -5,11 +5,16 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/polidog/slack-shell/internal/cache"
 	"github.com/polidog/slack-shell/internal/config"
+	"github.com/polidog/slack-shell/internal/notification"
+	"github.com/polidog/slack-shell/internal/platform"
 	"github.com/polidog/slack-shell/internal/slack"
 	"github.com/polidog/slack-shell/internal/version"
 )
@@ -18,15 +23,23 @@ import (
 type Executor struct {
 	client         *slack.Client
 	channels       []slack.Channel
+	publicChannels []slack.Channel // all public channels, loaded on the first cd to an unjoined #channel
 	dms            []slack.Channel
 	userNames      map[string]string    // In-memory cache for backward compatibility
 	userCache      *cache.UserCache     // Persistent cache
 	channelCache   *cache.ChannelCache  // Persistent channel cache
 	currentChannel *slack.Channel
+	prevChannel    *slack.Channel // Channel left by the last cd or .., for "cd -"
+	channelStack   []*slack.Channel // pushd/popd stack; nil entries are the channel list
 	workspaceName  string
 	promptConfig   *config.PromptConfig
 	displayConfig  *config.DisplayConfig
+	completion     *config.CompletionConfig
+	notifyMgr      *notification.Manager // Unread counts for ls
+	presence       map[string]presenceEntry // userID -> recently fetched presence
+	outputFormat   OutputFormat
 	hasAppToken    bool
+	realtimeClient *slack.RealtimeClient
 }
 
 // NewExecutor creates a new command executor
@@ -130,6 +143,13 @@ type ExecuteResult struct {
 	Error           error
 	NeedLoad        bool         // Indicates if we need to load data first
 	SwitchWorkspace *SwitchWorkspaceResult // Indicates workspace switch is requested
+	Confirm         *ConfirmRequest        // Indicates the command needs a y/n confirmation
+}
+
+// ConfirmRequest describes an action that runs only after the user confirms it
+type ConfirmRequest struct {
+	Prompt string
+	Action func() ExecuteResult
 }
 
 // SwitchWorkspaceResult contains info for switching workspace
@@ -170,6 +190,52 @@ func (e *Executor) Execute(cmd Command) ExecuteResult {
 		return e.executeWhoami()
 	case CmdShow:
 		return e.executeShow(cmd)
+	case CmdEdit:
+		return e.executeEdit(cmd)
+	case CmdRm:
+		return e.executeRm(cmd)
+	case CmdReply:
+		return e.executeReply(cmd)
+	case CmdPin:
+		return e.executePin(cmd, true)
+	case CmdUnpin:
+		return e.executePin(cmd, false)
+	case CmdPins:
+		return e.executePins()
+	case CmdStatus:
+		return e.executeStatus(cmd)
+	case CmdExport:
+		return e.executeExport(cmd)
+	case CmdMembers:
+		return e.executeMembers(cmd)
+	case CmdThread:
+		return e.executeThread(cmd)
+	case CmdRefresh:
+		return e.executeRefresh()
+	case CmdMute:
+		return e.executeMute(cmd, true)
+	case CmdUnmute:
+		return e.executeMute(cmd, false)
+	case CmdDnd:
+		return e.executeDnd(cmd)
+	case CmdConnection:
+		return e.executeConnection()
+	case CmdNotify:
+		return e.executeNotify(cmd)
+	case CmdAuth:
+		return e.executeAuth(cmd)
+	case CmdUsers:
+		return e.executeUsers(cmd)
+	case CmdPushd:
+		return e.executePushd(cmd)
+	case CmdPopd:
+		return e.executePopd()
+	case CmdDirs:
+		return e.executeDirs()
+	case CmdLink:
+		return e.executeLink(cmd)
+	case CmdOpen:
+		return e.executeOpen(cmd)
 	default:
 		return ExecuteResult{Output: "Unknown command. Type 'help' for available commands."}
 	}
@@ -179,6 +245,11 @@ func (e *Executor) executeLs(cmd Command) ExecuteResult {
 	// Check if we should only show DMs
 	dmOnly := len(cmd.Args) > 0 && cmd.Args[0] == "dm"
 
+	sortBy := cmd.Flags["sort"]
+	if sortBy != "" && sortBy != "name" && sortBy != "unread" {
+		return ExecuteResult{Output: "Usage: ls [dm] [--sort name|unread]"}
+	}
+
 	// Check if we should force refresh the cache
 	forceRefresh := cmd.GetFlagBool("r") || cmd.GetFlagBool("refresh")
 
@@ -229,24 +300,154 @@ func (e *Executor) executeLs(cmd Command) ExecuteResult {
 		}
 	}
 
+	channels := e.sortChannels(e.channels, sortBy, func(ch slack.Channel) string { return ch.Name })
+	dms := e.sortChannels(e.dms, sortBy, func(dm slack.Channel) string {
+		if name, ok := e.userNames[dm.UserID]; ok {
+			return name
+		}
+		return dm.UserID
+	})
+
 	if dmOnly {
-		return ExecuteResult{Output: FormatDMList(e.dms, e.userNames)}
+		return ExecuteResult{Output: FormatDMList(dms, e.userNames, e.unreadCounts(dms), e.dmPresence(dms), e.outputFormat)}
 	}
 
-	return ExecuteResult{Output: FormatChannelList(e.channels, e.dms, e.userNames)}
+	return ExecuteResult{Output: FormatChannelList(channels, dms, e.userNames, e.unreadCounts(channels, dms), e.outputFormat)}
+}
+
+// unreadCounts returns the unread count for each of the given channels that
+// has unread messages. Returns nil when notifications are not available.
+func (e *Executor) unreadCounts(lists ...[]slack.Channel) map[string]int {
+	if e.notifyMgr == nil {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, list := range lists {
+		for _, ch := range list {
+			if n := e.notifyMgr.GetUnreadForChannel(ch.ID); n > 0 {
+				counts[ch.ID] = n
+			}
+		}
+	}
+	return counts
+}
+
+// presenceEntry is a cached presence lookup
+type presenceEntry struct {
+	presence  string
+	fetchedAt time.Time
+}
+
+const (
+	// presenceTTL is how long a fetched presence is reused
+	presenceTTL = time.Minute
+	// presenceFetchLimit caps the presence lookups per ls dm, since
+	// users.getPresence is rate limited
+	presenceFetchLimit = 20
+)
+
+// userPresence returns a user's presence ("active" or "away"), fetching it
+// if the cached value is missing or stale. It returns "" if unknown.
+func (e *Executor) userPresence(userID string) string {
+	if entry, ok := e.presence[userID]; ok && time.Since(entry.fetchedAt) < presenceTTL {
+		return entry.presence
+	}
+	// Failures are cached too, so a missing scope doesn't cost a request
+	// every time the prompt is drawn
+	presence, _ := e.client.GetUserPresence(userID)
+	if e.presence == nil {
+		e.presence = make(map[string]presenceEntry)
+	}
+	e.presence[userID] = presenceEntry{presence: presence, fetchedAt: time.Now()}
+	return presence
+}
+
+// dmPresence returns the presence of the other user in each DM, keyed by
+// user ID. At most presenceFetchLimit uncached users are looked up.
+func (e *Executor) dmPresence(dms []slack.Channel) map[string]string {
+	result := make(map[string]string)
+	fetched := 0
+	for _, dm := range dms {
+		if dm.UserID == "" {
+			continue
+		}
+		if entry, ok := e.presence[dm.UserID]; !ok || time.Since(entry.fetchedAt) >= presenceTTL {
+			if fetched >= presenceFetchLimit {
+				continue
+			}
+			fetched++
+		}
+		if presence := e.userPresence(dm.UserID); presence != "" {
+			result[dm.UserID] = presence
+		}
+	}
+	return result
+}
+
+// sortChannels returns a sorted copy of channels. sortBy is "name"
+// (alphabetical by nameOf), "unread" (most unread first), or "" to keep
+// API order.
+func (e *Executor) sortChannels(channels []slack.Channel, sortBy string, nameOf func(slack.Channel) string) []slack.Channel {
+	if sortBy == "" || len(channels) == 0 {
+		return channels
+	}
+
+	sorted := make([]slack.Channel, len(channels))
+	copy(sorted, channels)
+
+	switch sortBy {
+	case "name":
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return strings.ToLower(nameOf(sorted[i])) < strings.ToLower(nameOf(sorted[j]))
+		})
+	case "unread":
+		if e.notifyMgr == nil {
+			return sorted
+		}
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return e.notifyMgr.GetUnreadForChannel(sorted[i].ID) > e.notifyMgr.GetUnreadForChannel(sorted[j].ID)
+		})
+	}
+	return sorted
 }
 
 func (e *Executor) executeCd(cmd Command) ExecuteResult {
-	if len(cmd.Args) == 0 {
+	args := cmd.Args
+	join := cmd.GetFlagBool("join")
+	// "cd --join #channel" parses the channel as the flag value
+	if v := cmd.Flags["join"]; join && v != "true" {
+		args = append([]string{v}, args...)
+	}
+
+	if len(args) == 0 {
 		return ExecuteResult{Output: "Usage: cd #channel or cd @user"}
 	}
 
-	target := cmd.Args[0]
+	target := args[0]
+
+	// Swap back to the previous channel
+	if target == "-" {
+		if e.prevChannel == nil {
+			return ExecuteResult{Error: fmt.Errorf("no previous channel")}
+		}
+		e.currentChannel, e.prevChannel = e.prevChannel, e.currentChannel
+		return ExecuteResult{Output: "Returned to " + e.channelLabel(e.currentChannel)}
+	}
+
+	from := e.currentChannel
+	result := e.enterTarget(target, join)
+	if result.Error == nil && from != nil && e.currentChannel != nil && e.currentChannel.ID != from.ID {
+		e.prevChannel = from
+	}
+	return result
+}
 
+// enterTarget enters the channel or DM named by target
+func (e *Executor) enterTarget(target string, join bool) ExecuteResult {
 	// Handle channel
 	if strings.HasPrefix(target, "#") {
 		channelName := strings.TrimPrefix(target, "#")
-		return e.enterChannel(channelName)
+		return e.enterChannel(channelName, join, true)
 	}
 
 	// Handle DM
@@ -257,7 +458,7 @@ func (e *Executor) executeCd(cmd Command) ExecuteResult {
 
 	// Try without prefix - could be either
 	// First try as channel
-	result := e.enterChannel(target)
+	result := e.enterChannel(target, join, false)
 	if result.Error == nil {
 		return result
 	}
@@ -266,7 +467,69 @@ func (e *Executor) executeCd(cmd Command) ExecuteResult {
 	return e.enterDM(target)
 }
 
-func (e *Executor) enterChannel(name string) ExecuteResult {
+// executePushd enters a channel or DM, remembering the current one on the
+// stack for popd
+func (e *Executor) executePushd(cmd Command) ExecuteResult {
+	if len(cmd.Args) == 0 {
+		return ExecuteResult{Output: "Usage: pushd #channel or pushd @user"}
+	}
+
+	from := e.currentChannel
+	result := e.enterTarget(cmd.Args[0], false)
+	if result.Error != nil {
+		return result
+	}
+	e.channelStack = append(e.channelStack, from)
+	if from != nil {
+		e.prevChannel = from
+	}
+	return ExecuteResult{Output: result.Output + "\n" + e.formatDirs()}
+}
+
+// executePopd returns to the channel saved by the last pushd
+func (e *Executor) executePopd() ExecuteResult {
+	if len(e.channelStack) == 0 {
+		return ExecuteResult{Error: fmt.Errorf("channel stack is empty")}
+	}
+
+	top := e.channelStack[len(e.channelStack)-1]
+	e.channelStack = e.channelStack[:len(e.channelStack)-1]
+	if e.currentChannel != nil {
+		e.prevChannel = e.currentChannel
+	}
+	e.currentChannel = top
+	return ExecuteResult{Output: "Returned to " + e.channelLabel(top) + "\n" + e.formatDirs()}
+}
+
+func (e *Executor) executeDirs() ExecuteResult {
+	return ExecuteResult{Output: e.formatDirs()}
+}
+
+// formatDirs lists the current channel followed by the stack, most recent
+// first, like the shell's dirs
+func (e *Executor) formatDirs() string {
+	labels := []string{e.channelLabel(e.currentChannel)}
+	for i := len(e.channelStack) - 1; i >= 0; i-- {
+		labels = append(labels, e.channelLabel(e.channelStack[i]))
+	}
+	return strings.Join(labels, " ")
+}
+
+// channelLabel names a channel for messages: "#name" or "DM with @user",
+// or "~" for the channel list
+func (e *Executor) channelLabel(ch *slack.Channel) string {
+	if ch == nil {
+		return "~"
+	}
+	if ch.IsIM {
+		return "DM with @" + e.GetUserName(ch.UserID)
+	}
+	return "#" + ch.Name
+}
+
+// enterChannel enters a joined channel by name. With public set (an explicit
+// #name), public channels the user hasn't joined are looked up as well.
+func (e *Executor) enterChannel(name string, join, public bool) ExecuteResult {
 	// Load channels if needed
 	if e.channels == nil {
 		channels, err := e.client.GetChannels()
@@ -284,67 +547,884 @@ func (e *Executor) enterChannel(name string) ExecuteResult {
 		}
 	}
 
-	return ExecuteResult{Error: fmt.Errorf("channel not found: %s", name)}
+	if !public {
+		return ExecuteResult{Error: fmt.Errorf("channel not found: %s", name)}
+	}
+
+	// Fall back to public channels the user hasn't joined
+	if e.publicChannels == nil {
+		publicChannels, err := e.client.GetAllPublicChannels()
+		if err != nil {
+			return ExecuteResult{Error: fmt.Errorf("failed to load public channels: %w", err)}
+		}
+		e.publicChannels = publicChannels
+	}
+	for _, ch := range e.publicChannels {
+		if !strings.EqualFold(ch.Name, name) {
+			continue
+		}
+
+		if join {
+			if err := e.client.JoinChannelAsUser(ch.ID); err != nil {
+				return ExecuteResult{Error: fmt.Errorf("failed to join #%s: %w", ch.Name, err)}
+			}
+			e.channels = append(e.channels, ch)
+			e.currentChannel = &ch
+			return ExecuteResult{Output: fmt.Sprintf("Joined and entered #%s", ch.Name)}
+		}
+
+		e.currentChannel = &ch
+		return ExecuteResult{Output: fmt.Sprintf("Entered #%s (read-only: not a member, use 'cd --join #%s' to join)", ch.Name, ch.Name)}
+	}
+
+	return ExecuteResult{Error: fmt.Errorf("channel not found: %s", name)}
+}
+
+func (e *Executor) enterDM(userName string) ExecuteResult {
+	dm, err := e.findDM(userName)
+	if err != nil {
+		return ExecuteResult{Error: err}
+	}
+	e.currentChannel = dm
+	displayName := e.userNames[dm.UserID]
+	if displayName == "" {
+		displayName = dm.UserID
+	}
+	return ExecuteResult{Output: fmt.Sprintf("Entered DM with @%s", displayName)}
+}
+
+// findDM finds a DM by the other user's name or ID
+func (e *Executor) findDM(userName string) (*slack.Channel, error) {
+	// Load DMs if needed
+	if e.dms == nil {
+		dms, err := e.client.GetDMs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load DMs: %w", err)
+		}
+		e.dms = dms
+
+		// Load user names (only those not already cached)
+		userIDs := make([]string, 0, len(e.dms))
+		for _, dm := range e.dms {
+			if dm.UserID != "" {
+				if _, ok := e.userNames[dm.UserID]; !ok {
+					userIDs = append(userIDs, dm.UserID)
+				}
+			}
+		}
+		if len(userIDs) > 0 {
+			users, err := e.client.GetUsersInfo(userIDs)
+			if err == nil && users != nil {
+				for _, u := range *users {
+					e.setUserFull(u.ID, u.Name, u.Profile.DisplayName, u.RealName)
+				}
+			}
+		}
+	}
+
+	// Find the DM by user name
+	for i, dm := range e.dms {
+		name := e.userNames[dm.UserID]
+		if strings.EqualFold(name, userName) || strings.EqualFold(dm.UserID, userName) {
+			return &e.dms[i], nil
+		}
+	}
+	return nil, fmt.Errorf("user not found: %s", userName)
+}
+
+func (e *Executor) executeBack() ExecuteResult {
+	if e.currentChannel == nil {
+		return ExecuteResult{Output: "Already at channel list"}
+	}
+	e.prevChannel = e.currentChannel
+	e.currentChannel = nil
+	return ExecuteResult{Output: "Returned to channel list"}
+}
+
+func (e *Executor) executeCat(cmd Command) ExecuteResult {
+	if e.currentChannel == nil {
+		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
+	}
+
+	// Get message count from -n flag
+	limit := cmd.GetFlagInt("n", 20)
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	// --from N skips the N most recent messages
+	if _, ok := cmd.Flags["from"]; ok {
+		from := cmd.GetFlagInt("from", -1)
+		if from < 0 {
+			return ExecuteResult{Output: "Usage: cat [-n N] [--from OFFSET]"}
+		}
+		return e.catRange(from, limit, cmd.GetFlagBool("threads"))
+	}
+
+	// Get messages
+	messages, err := e.client.GetMessages(e.currentChannel.ID, limit)
+	if err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to load messages: %w", err)}
+	}
+
+	var latestReplies map[string]slack.Message
+	if cmd.GetFlagBool("threads") {
+		latestReplies = e.loadLatestReplies(messages)
+	}
+
+	e.loadMessageUsers(messages)
+
+	return ExecuteResult{Output: FormatMessagesWithReplies(messages, latestReplies, e.userNames, e.channelNames(), e.displayConfig, e.outputFormat)}
+}
+
+const (
+	// maxLatestReplyFetches caps the threads cat --threads looks into
+	maxLatestReplyFetches = 20
+	// latestReplyWorkers is how many threads cat --threads loads at once
+	latestReplyWorkers = 4
+)
+
+// loadLatestReplies fetches the latest reply of the most recent threads in
+// messages, keyed by the parent's timestamp, and resolves their authors'
+// names. Threads that fail to load are left out.
+func (e *Executor) loadLatestReplies(messages []slack.Message) map[string]slack.Message {
+	var parents []slack.Message
+	for i := len(messages) - 1; i >= 0 && len(parents) < maxLatestReplyFetches; i-- {
+		if messages[i].ReplyCount > 0 && messages[i].LatestReply != "" {
+			parents = append(parents, messages[i])
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		replies = make(map[string]slack.Message)
+		sem     = make(chan struct{}, latestReplyWorkers)
+	)
+	channelID := e.currentChannel.ID
+	for _, parent := range parents {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(parent slack.Message) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			reply, err := e.client.GetThreadReply(channelID, parent.Timestamp, parent.LatestReply)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			replies[parent.Timestamp] = *reply
+			mu.Unlock()
+		}(parent)
+	}
+	wg.Wait()
+
+	authors := make([]slack.Message, 0, len(replies))
+	for _, reply := range replies {
+		authors = append(authors, reply)
+	}
+	e.loadMessageUsers(authors)
+
+	return replies
+}
+
+// maxCatHistory caps how far back cat --from pages through history
+const maxCatHistory = 1000
+
+// catRange shows limit messages after skipping the from most recent ones,
+// paging back through history as needed
+func (e *Executor) catRange(from, limit int, threads bool) ExecuteResult {
+	if from+limit > maxCatHistory {
+		return ExecuteResult{Output: fmt.Sprintf("cat --from can only reach %d messages back.", maxCatHistory)}
+	}
+
+	// Pages come oldest first; collect them so all stays oldest first
+	var all []slack.Message
+	latest := ""
+	hasMore := true
+	for len(all) < from+limit && hasMore {
+		result, err := e.client.GetMessagesWithPagination(e.currentChannel.ID, 100, latest)
+		if err != nil {
+			return ExecuteResult{Error: fmt.Errorf("failed to load messages: %w", err)}
+		}
+		if len(result.Messages) == 0 {
+			break
+		}
+		all = append(result.Messages, all...)
+		latest = result.Messages[0].Timestamp
+		hasMore = result.HasMore
+	}
+
+	if len(all) <= from {
+		return ExecuteResult{Output: fmt.Sprintf("History exhausted: only %d message(s) in this channel.", len(all))}
+	}
+
+	end := len(all) - from
+	start := end - limit
+	if start < 0 {
+		start = 0
+	}
+	messages := all[start:end]
+
+	var latestReplies map[string]slack.Message
+	if threads {
+		latestReplies = e.loadLatestReplies(messages)
+	}
+
+	e.loadMessageUsers(messages)
+
+	output := FormatMessagesWithReplies(messages, latestReplies, e.userNames, e.channelNames(), e.displayConfig, e.outputFormat)
+	if start == 0 && !hasMore && e.outputFormat == OutputText {
+		output += "(beginning of channel history)\n"
+	}
+	return ExecuteResult{Output: output}
+}
+
+// executeRefresh drops cached channels, DMs and user names so the next
+// commands fetch them from the API
+func (e *Executor) executeRefresh() ExecuteResult {
+	channels := len(e.channels)
+	dms := len(e.dms)
+	users := len(e.userNames)
+	if e.channelCache != nil {
+		channels = max(channels, e.channelCache.ChannelsSize())
+		dms = max(dms, e.channelCache.DMsSize())
+		e.channelCache.Clear()
+	}
+	if e.userCache != nil {
+		users = max(users, e.userCache.Size())
+		e.userCache.Clear()
+	}
+
+	e.channels = nil
+	e.publicChannels = nil
+	e.dms = nil
+	e.client.ClearUsersList()
+	// Clear in place: the map is shared with live and browse modes
+	for id := range e.userNames {
+		delete(e.userNames, id)
+	}
+
+	return ExecuteResult{Output: fmt.Sprintf("Cache cleared: %d channels, %d DMs, %d users.", channels, dms, users)}
+}
+
+// executeMute mutes or unmutes notifications for a channel (default: the
+// current channel)
+func (e *Executor) executeMute(cmd Command, mute bool) ExecuteResult {
+	if e.notifyMgr == nil {
+		return ExecuteResult{Output: "Notifications are not available."}
+	}
+
+	ch := e.currentChannel
+	if len(cmd.Args) > 0 {
+		var err error
+		ch, err = e.resolveChannel(cmd.Args[0])
+		if err != nil {
+			return ExecuteResult{Error: err}
+		}
+	}
+	if ch == nil {
+		return ExecuteResult{Output: "Usage: mute|unmute [#channel]"}
+	}
+
+	name := ch.Name
+	label := "#" + name
+	if ch.IsIM {
+		name = e.GetChannelName(ch.ID)
+		label = "@" + name
+	}
+
+	if mute {
+		e.notifyMgr.MuteChannel(ch.ID)
+		return ExecuteResult{Output: fmt.Sprintf("Muted %s.", label)}
+	}
+
+	// mute_channels in the config may list the channel by name
+	for _, key := range []string{ch.ID, name, "#" + name} {
+		e.notifyMgr.UnmuteChannel(key)
+	}
+	return ExecuteResult{Output: fmt.Sprintf("Unmuted %s.", label)}
+}
+
+// executeDnd shows or sets Do Not Disturb mode
+func (e *Executor) executeDnd(cmd Command) ExecuteResult {
+	if len(cmd.Args) > 0 && strings.ToLower(cmd.Args[0]) == "slack" {
+		return e.executeSlackDnd(cmd.Args[1:])
+	}
+
+	if e.notifyMgr == nil {
+		return ExecuteResult{Output: "Notifications are not available."}
+	}
+
+	if len(cmd.Args) > 0 {
+		switch strings.ToLower(cmd.Args[0]) {
+		case "on":
+			e.notifyMgr.SetDND(true)
+		case "off":
+			e.notifyMgr.SetDND(false)
+		default:
+			return ExecuteResult{Output: "Usage: dnd [on|off] | dnd slack [minutes|off]"}
+		}
+	}
+
+	if e.notifyMgr.IsDND() {
+		return ExecuteResult{Output: "Do Not Disturb: on"}
+	}
+	return ExecuteResult{Output: "Do Not Disturb: off"}
+}
+
+// executeSlackDnd shows or sets Do Not Disturb on the Slack side, which
+// pauses notifications on every device, not just this client
+func (e *Executor) executeSlackDnd(args []string) ExecuteResult {
+	if len(args) > 0 {
+		if strings.ToLower(args[0]) == "off" {
+			if err := e.client.EndSlackDND(); err != nil {
+				return ExecuteResult{Error: fmt.Errorf("failed to end Slack Do Not Disturb: %w", err)}
+			}
+		} else {
+			minutes, err := strconv.Atoi(args[0])
+			if err != nil || minutes <= 0 {
+				return ExecuteResult{Output: "Usage: dnd slack [minutes|off]"}
+			}
+			if err := e.client.SetSlackDND(minutes); err != nil {
+				return ExecuteResult{Error: fmt.Errorf("failed to set Slack Do Not Disturb: %w", err)}
+			}
+		}
+	}
+
+	until, err := e.client.GetSlackDND()
+	if err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to get Slack Do Not Disturb: %w", err)}
+	}
+	if until.IsZero() {
+		return ExecuteResult{Output: "Slack Do Not Disturb: off"}
+	}
+	return ExecuteResult{Output: fmt.Sprintf("Slack Do Not Disturb: on (until %s)", until.Format("15:04"))}
+}
+
+// resolveChannel finds a joined channel by name (with or without '#')
+func (e *Executor) resolveChannel(name string) (*slack.Channel, error) {
+	name = strings.TrimPrefix(name, "#")
+	if e.channels == nil {
+		channels, err := e.client.GetChannels()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load channels: %w", err)
+		}
+		e.channels = channels
+	}
+	for i := range e.channels {
+		if strings.EqualFold(e.channels[i].Name, name) {
+			return &e.channels[i], nil
+		}
+	}
+	return nil, fmt.Errorf("channel not found: %s", name)
+}
+
+// channelNames maps the IDs of loaded channels to their names
+func (e *Executor) channelNames() map[string]string {
+	names := make(map[string]string, len(e.channels))
+	for _, ch := range e.channels {
+		names[ch.ID] = ch.Name
+	}
+	return names
+}
+
+// loadMessageUsers resolves user names for message authors that are not
+// already cached
+func (e *Executor) loadMessageUsers(messages []slack.Message) {
+	userIDs := make(map[string]bool)
+	for _, msg := range messages {
+		if msg.User != "" && msg.UserName == "" {
+			if _, ok := e.userNames[msg.User]; !ok {
+				userIDs[msg.User] = true
+			}
+		}
+	}
+
+	if len(userIDs) > 0 {
+		ids := make([]string, 0, len(userIDs))
+		for id := range userIDs {
+			ids = append(ids, id)
+		}
+		users, err := e.client.GetUsersInfo(ids)
+		if err == nil && users != nil {
+			for _, u := range *users {
+				e.setUserFull(u.ID, u.Name, u.Profile.DisplayName, u.RealName)
+			}
+		}
+	}
+}
+
+func (e *Executor) executeSend(cmd Command) ExecuteResult {
+	if e.currentChannel == nil {
+		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
+	}
+
+	// --reply-to N or --thread <ts> posts into a thread instead of the channel
+	threadTS := cmd.Flags["thread"]
+	replyTo, isReply := cmd.Flags["reply-to"]
+	if threadTS == "true" || replyTo == "true" {
+		return ExecuteResult{Output: "Usage: send [--reply-to N | --thread <ts>] <message>"}
+	}
+
+	message := cmd.RawArgs
+	if isReply || threadTS != "" {
+		// RawArgs still contains the flags
+		message = trimLeadingFlags(message, "reply-to", "thread")
+	}
+	if message == "" && len(cmd.Args) > 0 {
+		message = strings.Join(cmd.Args, " ")
+	}
+	// A message quoted as a whole, e.g. to keep ; or && in it, is sent
+	// without the quotes
+	if len(cmd.Args) == 1 && len(message) >= 2 && (message[0] == '"' || message[0] == '\'') && message[len(message)-1] == message[0] {
+		message = cmd.Args[0]
+	}
+
+	if message == "" {
+		return ExecuteResult{Output: "Usage: send [--reply-to N | --thread <ts>] <message>"}
+	}
+
+	// The input line is single-line, so allow \n and \t escapes
+	return e.postMessage(cmd, decodeEscapes(message))
+}
+
+// postMessage sends message to the current channel, or into a thread when
+// cmd has a --reply-to or --thread flag
+func (e *Executor) postMessage(cmd Command, message string) ExecuteResult {
+	if e.currentChannel == nil {
+		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
+	}
+
+	threadTS := cmd.Flags["thread"]
+	if replyTo, isReply := cmd.Flags["reply-to"]; isReply {
+		offset, err := strconv.Atoi(replyTo)
+		if err != nil {
+			return ExecuteResult{Output: "Usage: send [--reply-to N | --thread <ts>] <message>"}
+		}
+		target, err := e.messageAtOffset(offset)
+		if err != nil {
+			return ExecuteResult{Error: err}
+		}
+		threadTS = threadRoot(target)
+	}
+
+	// Convert @username mentions to <@USER_ID> format
+	message = e.convertMentions(message)
+
+	if threadTS != "" {
+		if _, err := e.client.PostThreadReply(e.currentChannel.ID, threadTS, message); err != nil {
+			return ExecuteResult{Error: fmt.Errorf("failed to send reply: %w", err)}
+		}
+		return ExecuteResult{Output: "Reply sent."}
+	}
+
+	_, err := e.client.PostMessage(e.currentChannel.ID, message)
+	if err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to send message: %w", err)}
+	}
+
+	return ExecuteResult{Output: "Message sent."}
+}
+
+// decodeEscapes expands \n, \t and \\ escape sequences. Other backslashes
+// are kept as-is.
+func decodeEscapes(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case 'n':
+				sb.WriteByte('\n')
+				i++
+				continue
+			case 't':
+				sb.WriteByte('\t')
+				i++
+				continue
+			case '\\':
+				sb.WriteByte('\\')
+				i++
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// threadRoot returns the timestamp of the thread a message belongs to, or
+// the message's own timestamp if it is not a reply
+func threadRoot(msg *slack.Message) string {
+	if msg.ThreadTS != "" {
+		return msg.ThreadTS
+	}
+	return msg.Timestamp
+}
+
+// ownMessageSearchLimit is how many recent messages are scanned when looking
+// for the current user's latest message
+const ownMessageSearchLimit = 50
+
+// messageAtOffset returns the Nth most recent message in the current channel
+// (1 = latest)
+func (e *Executor) messageAtOffset(offset int) (*slack.Message, error) {
+	if offset <= 0 || offset > 100 {
+		return nil, fmt.Errorf("invalid message offset: %d (must be 1-100)", offset)
+	}
+	messages, err := e.client.GetMessages(e.currentChannel.ID, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load messages: %w", err)
+	}
+	if len(messages) < offset {
+		return nil, fmt.Errorf("only %d message(s) in this channel", len(messages))
+	}
+	msg := messages[len(messages)-offset]
+	return &msg, nil
+}
+
+// findTargetMessage resolves a message in the current channel for commands
+// that act on a recent message. If the -n flag is given, the Nth most recent
+// message is returned (1 = latest); otherwise the current user's most recent
+// message is returned. Messages not authored by the current user are refused.
+func (e *Executor) findTargetMessage(cmd Command) (*slack.Message, error) {
+	userID := e.client.GetUserID()
+
+	if _, ok := cmd.Flags["n"]; ok {
+		offset := cmd.GetFlagInt("n", 0)
+		msg, err := e.messageAtOffset(offset)
+		if err != nil {
+			return nil, err
+		}
+		if msg.User != userID {
+			return nil, fmt.Errorf("message %d is not yours", offset)
+		}
+		return msg, nil
+	}
+
+	messages, err := e.client.GetMessages(e.currentChannel.ID, ownMessageSearchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load messages: %w", err)
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].User == userID {
+			return &messages[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no message of yours in the last %d messages", ownMessageSearchLimit)
+}
+
+func (e *Executor) executeEdit(cmd Command) ExecuteResult {
+	if e.currentChannel == nil {
+		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
+	}
+
+	// "edit 2 <text>" is short for "edit -n 2 <text>"
+	args := cmd.Args
+	if _, ok := cmd.Flags["n"]; !ok && len(args) > 0 {
+		if n, err := strconv.Atoi(args[0]); err == nil && n > 0 {
+			cmd.Flags["n"] = args[0]
+			args = args[1:]
+		}
+	}
+
+	target, err := e.findTargetMessage(cmd)
+	if err != nil {
+		return ExecuteResult{Error: err}
+	}
+
+	// Without new text, print the current text to copy and edit
+	text := strings.Join(args, " ")
+	if text == "" {
+		return ExecuteResult{Output: target.Text}
+	}
+
+	// Convert @username mentions to <@USER_ID> format
+	text = e.convertMentions(text)
+
+	if err := e.client.UpdateMessage(e.currentChannel.ID, target.Timestamp, text); err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to edit message: %w", err)}
+	}
+
+	return ExecuteResult{Output: fmt.Sprintf("Message updated: %s", ConvertEmoji(ResolveMentions(text, e.userNames)))}
+}
+
+// executeLink prints the permalink of the Nth most recent message
+// (default: the latest)
+func (e *Executor) executeLink(cmd Command) ExecuteResult {
+	if e.currentChannel == nil {
+		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
+	}
+
+	offset := 1
+	if len(cmd.Args) > 0 {
+		n, err := strconv.Atoi(cmd.Args[0])
+		if err != nil {
+			return ExecuteResult{Output: "Usage: link [N]"}
+		}
+		offset = n
+	}
+
+	target, err := e.messageAtOffset(offset)
+	if err != nil {
+		return ExecuteResult{Error: err}
+	}
+
+	url, err := e.client.GetPermalink(e.currentChannel.ID, target.Timestamp)
+	if err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to get link: %w", err)}
+	}
+	return ExecuteResult{Output: url}
+}
+
+// executeOpen opens the current channel, or the named channel or DM, in the
+// browser or Slack app
+func (e *Executor) executeOpen(cmd Command) ExecuteResult {
+	channel := e.currentChannel
+	if len(cmd.Args) > 0 {
+		var err error
+		channel, err = e.resolveTarget(cmd.Args[0])
+		if err != nil {
+			return ExecuteResult{Error: err}
+		}
+	}
+	if channel == nil {
+		return ExecuteResult{Output: "Usage: open [#channel|@user] (opens the current channel by default)"}
+	}
+
+	url := fmt.Sprintf("https://app.slack.com/client/%s/%s", e.client.GetTeamID(), channel.ID)
+	if err := platform.OpenBrowser(url); err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to open %s: %w", url, err)}
+	}
+	return ExecuteResult{Output: "Opened " + url}
+}
+
+// resolveTarget finds the channel or DM named by target without entering it
+func (e *Executor) resolveTarget(target string) (*slack.Channel, error) {
+	if strings.HasPrefix(target, "#") {
+		return e.resolveChannel(target)
+	}
+	if !strings.HasPrefix(target, "@") {
+		if channel, err := e.resolveChannel(target); err == nil {
+			return channel, nil
+		}
+	}
+	return e.findDM(strings.TrimPrefix(target, "@"))
+}
+
+func (e *Executor) executeReply(cmd Command) ExecuteResult {
+	if e.currentChannel == nil {
+		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
+	}
+
+	text := strings.Join(cmd.Args, " ")
+	if _, ok := cmd.Flags["n"]; !ok || text == "" {
+		return ExecuteResult{Output: "Usage: reply -n N <message>"}
+	}
+
+	target, err := e.messageAtOffset(cmd.GetFlagInt("n", 0))
+	if err != nil {
+		return ExecuteResult{Error: err}
+	}
+
+	// Replies to a thread reply go to the thread's parent
+	threadTS := threadRoot(target)
+
+	// Convert @username mentions to <@USER_ID> format
+	text = e.convertMentions(text)
+
+	if _, err := e.client.PostThreadReply(e.currentChannel.ID, threadTS, text); err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to send reply: %w", err)}
+	}
+
+	return ExecuteResult{Output: fmt.Sprintf("Reply sent: %s", ConvertEmoji(ResolveMentions(text, e.userNames)))}
+}
+
+func (e *Executor) executeThread(cmd Command) ExecuteResult {
+	if e.currentChannel == nil {
+		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
+	}
+
+	if len(cmd.Args) == 0 {
+		return ExecuteResult{Output: "Usage: thread N (N = message number, 1 = latest)"}
+	}
+	offset, err := strconv.Atoi(cmd.Args[0])
+	if err != nil {
+		return ExecuteResult{Output: "Usage: thread N (N = message number, 1 = latest)"}
+	}
+
+	target, err := e.messageAtOffset(offset)
+	if err != nil {
+		return ExecuteResult{Error: err}
+	}
+
+	threadTS := target.Timestamp
+	if target.ThreadTS != "" {
+		threadTS = target.ThreadTS
+	} else if target.ReplyCount == 0 {
+		return ExecuteResult{Error: fmt.Errorf("message %d has no replies", offset)}
+	}
+
+	messages, err := e.client.GetThreadReplies(e.currentChannel.ID, threadTS)
+	if err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to load thread: %w", err)}
+	}
+	if len(messages) <= 1 {
+		return ExecuteResult{Error: fmt.Errorf("message %d has no replies", offset)}
+	}
+
+	e.loadMessageUsers(messages)
+
+	return ExecuteResult{Output: FormatThread(messages, e.userNames, e.channelNames(), e.displayConfig, e.outputFormat)}
+}
+
+func (e *Executor) executePin(cmd Command, pin bool) ExecuteResult {
+	if e.currentChannel == nil {
+		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
+	}
+
+	target, err := e.messageAtOffset(cmd.GetFlagInt("n", 1))
+	if err != nil {
+		return ExecuteResult{Error: err}
+	}
+
+	preview := truncatePreview(strings.ReplaceAll(ConvertEmoji(ResolveMentions(target.Text, e.userNames)), "\n", " "), 40)
+	if pin {
+		if err := e.client.AddPin(e.currentChannel.ID, target.Timestamp); err != nil {
+			return ExecuteResult{Error: pinError("pin", err)}
+		}
+		return ExecuteResult{Output: fmt.Sprintf("Pinned: %s", preview)}
+	}
+
+	if err := e.client.RemovePin(e.currentChannel.ID, target.Timestamp); err != nil {
+		return ExecuteResult{Error: pinError("unpin", err)}
+	}
+	return ExecuteResult{Output: fmt.Sprintf("Unpinned: %s", preview)}
+}
+
+func (e *Executor) executePins() ExecuteResult {
+	if e.currentChannel == nil {
+		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
+	}
+
+	messages, err := e.client.GetPins(e.currentChannel.ID)
+	if err != nil {
+		return ExecuteResult{Error: pinError("list pins", err)}
+	}
+	if len(messages) == 0 {
+		return ExecuteResult{Output: "No pinned messages."}
+	}
+
+	e.loadMessageUsers(messages)
+
+	return ExecuteResult{Output: FormatMessages(messages, e.userNames, e.channelNames(), e.displayConfig, e.outputFormat)}
 }
 
-func (e *Executor) enterDM(userName string) ExecuteResult {
-	// Load DMs if needed
-	if e.dms == nil {
-		dms, err := e.client.GetDMs()
-		if err != nil {
-			return ExecuteResult{Error: fmt.Errorf("failed to load DMs: %w", err)}
-		}
-		e.dms = dms
+// pinError turns Slack pin API errors into readable messages
+func pinError(action string, err error) error {
+	switch err.Error() {
+	case "already_pinned":
+		return fmt.Errorf("message is already pinned")
+	case "no_pin":
+		return fmt.Errorf("message is not pinned")
+	case "not_pinnable":
+		return fmt.Errorf("this message cannot be pinned")
+	case "permission_denied", "restricted_action", "cant_update_message":
+		return fmt.Errorf("failed to %s: you don't have permission in this channel", action)
+	case "missing_scope":
+		return fmt.Errorf("failed to %s: token is missing the pins:read/pins:write scope", action)
+	}
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
 
-		// Load user names (only those not already cached)
-		userIDs := make([]string, 0, len(e.dms))
-		for _, dm := range e.dms {
-			if dm.UserID != "" {
-				if _, ok := e.userNames[dm.UserID]; !ok {
-					userIDs = append(userIDs, dm.UserID)
-				}
-			}
+func (e *Executor) executeStatus(cmd Command) ExecuteResult {
+	if len(cmd.Args) == 0 {
+		return e.showStatus()
+	}
+
+	switch strings.ToLower(cmd.Args[0]) {
+	case "clear":
+		if err := e.client.ClearUserStatus(); err != nil {
+			return ExecuteResult{Error: fmt.Errorf("failed to clear status: %w", err)}
 		}
-		if len(userIDs) > 0 {
-			users, err := e.client.GetUsersInfo(userIDs)
-			if err == nil && users != nil {
-				for _, u := range *users {
-					e.setUserFull(u.ID, u.Name, u.Profile.DisplayName, u.RealName)
-				}
-			}
+		return ExecuteResult{Output: "Status cleared."}
+	case "away", "auto":
+		presence := strings.ToLower(cmd.Args[0])
+		if err := e.client.SetPresence(presence); err != nil {
+			return ExecuteResult{Error: fmt.Errorf("failed to set presence: %w", err)}
 		}
+		return ExecuteResult{Output: fmt.Sprintf("Presence set to %s.", presence)}
 	}
 
-	// Find the DM by user name
-	for _, dm := range e.dms {
-		name := e.userNames[dm.UserID]
-		if strings.EqualFold(name, userName) || strings.EqualFold(dm.UserID, userName) {
-			e.currentChannel = &dm
-			displayName := name
-			if displayName == "" {
-				displayName = dm.UserID
+	// The emoji may come before or after the text: status :coffee: "brb" 30m
+	var text, emojiCode string
+	var expiry time.Time
+	for i, arg := range cmd.Args {
+		if len(arg) > 2 && strings.HasPrefix(arg, ":") && strings.HasSuffix(arg, ":") {
+			emojiCode = arg
+			continue
+		}
+		if i == 0 {
+			text = arg
+			continue
+		}
+		if i == 1 && text == "" {
+			// After a leading emoji, text unless it's only a duration
+			if d, err := time.ParseDuration(arg); err != nil || d <= 0 {
+				text = arg
+				continue
 			}
-			return ExecuteResult{Output: fmt.Sprintf("Entered DM with @%s", displayName)}
 		}
+		d, err := time.ParseDuration(arg)
+		if err != nil || d <= 0 {
+			return ExecuteResult{Error: fmt.Errorf("invalid argument: %s (expected :emoji: or a duration like 30m, 1h)", arg)}
+		}
+		expiry = time.Now().Add(d)
 	}
 
-	return ExecuteResult{Error: fmt.Errorf("user not found: %s", userName)}
+	if err := e.client.SetUserStatus(text, emojiCode, expiry); err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to set status: %w", err)}
+	}
+
+	return ExecuteResult{Output: "Status set: " + formatStatus(text, emojiCode, expiry)}
 }
 
-func (e *Executor) executeBack() ExecuteResult {
-	if e.currentChannel == nil {
-		return ExecuteResult{Output: "Already at channel list"}
+// showStatus prints the user's current custom status
+func (e *Executor) showStatus() ExecuteResult {
+	text, emojiCode, expiry, err := e.client.GetUserStatus()
+	if err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to get status: %w", err)}
 	}
-	e.currentChannel = nil
-	return ExecuteResult{Output: "Returned to channel list"}
+	if text == "" && emojiCode == "" {
+		return ExecuteResult{Output: "No status set."}
+	}
+	return ExecuteResult{Output: "Status: " + formatStatus(text, emojiCode, expiry)}
 }
 
-func (e *Executor) executeCat(cmd Command) ExecuteResult {
+// formatStatus renders a custom status as "emoji text (until 15:04)"
+func formatStatus(text, emojiCode string, expiry time.Time) string {
+	status := text
+	if emojiCode != "" {
+		status = strings.TrimSpace(ConvertEmoji(emojiCode) + " " + text)
+	}
+	if !expiry.IsZero() {
+		status += fmt.Sprintf(" (until %s)", expiry.Format("15:04"))
+	}
+	return status
+}
+
+func (e *Executor) executeExport(cmd Command) ExecuteResult {
 	if e.currentChannel == nil {
 		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
 	}
 
-	// Get message count from -n flag
 	limit := cmd.GetFlagInt("n", 20)
 	if limit <= 0 {
 		limit = 20
@@ -353,61 +1433,82 @@ func (e *Executor) executeCat(cmd Command) ExecuteResult {
 		limit = 100
 	}
 
-	// Get messages
 	messages, err := e.client.GetMessages(e.currentChannel.ID, limit)
 	if err != nil {
 		return ExecuteResult{Error: fmt.Errorf("failed to load messages: %w", err)}
 	}
 
-	// Load user names for messages (only those not already cached)
-	userIDs := make(map[string]bool)
+	// Fetch replies for messages that start a thread
+	threads := make(map[string][]slack.Message)
+	allMessages := messages
 	for _, msg := range messages {
-		if msg.User != "" && msg.UserName == "" {
-			if _, ok := e.userNames[msg.User]; !ok {
-				userIDs[msg.User] = true
-			}
+		if msg.ReplyCount == 0 {
+			continue
 		}
-	}
-
-	if len(userIDs) > 0 {
-		ids := make([]string, 0, len(userIDs))
-		for id := range userIDs {
-			ids = append(ids, id)
+		replies, err := e.client.GetThreadReplies(e.currentChannel.ID, msg.Timestamp)
+		if err != nil {
+			return ExecuteResult{Error: fmt.Errorf("failed to load thread: %w", err)}
 		}
-		users, err := e.client.GetUsersInfo(ids)
-		if err == nil && users != nil {
-			for _, u := range *users {
-				e.setUserFull(u.ID, u.Name, u.Profile.DisplayName, u.RealName)
-			}
+		// The first reply is the parent message itself
+		if len(replies) > 0 && replies[0].Timestamp == msg.Timestamp {
+			replies = replies[1:]
 		}
+		threads[msg.Timestamp] = replies
+		allMessages = append(allMessages, replies...)
+	}
+
+	e.loadMessageUsers(allMessages)
+
+	channelName := "#" + e.currentChannel.Name
+	if e.currentChannel.IsIM {
+		channelName = "@" + e.GetUserName(e.currentChannel.UserID)
+	}
+	markdown := FormatMarkdown(channelName, messages, threads, e.userNames, e.displayConfig)
+
+	// Without a file argument, print the Markdown (e.g. for -c "export" > file)
+	if len(cmd.Args) == 0 {
+		return ExecuteResult{Output: markdown}
 	}
 
-	return ExecuteResult{Output: FormatMessages(messages, e.userNames)}
+	path, err := expandPath(cmd.Args[0])
+	if err != nil {
+		return ExecuteResult{Error: err}
+	}
+	if err := os.WriteFile(path, []byte(markdown), 0644); err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to write %s: %w", path, err)}
+	}
+	return ExecuteResult{Output: fmt.Sprintf("Exported %d messages to %s", len(messages), path)}
 }
 
-func (e *Executor) executeSend(cmd Command) ExecuteResult {
+func (e *Executor) executeRm(cmd Command) ExecuteResult {
 	if e.currentChannel == nil {
 		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
 	}
 
-	message := cmd.RawArgs
-	if message == "" && len(cmd.Args) > 0 {
-		message = strings.Join(cmd.Args, " ")
+	target, err := e.findTargetMessage(cmd)
+	if err != nil {
+		return ExecuteResult{Error: err}
 	}
 
-	if message == "" {
-		return ExecuteResult{Output: "Usage: send <message>"}
+	channelID := e.currentChannel.ID
+	deleteTarget := func() ExecuteResult {
+		if err := e.client.DeleteMessage(channelID, target.Timestamp); err != nil {
+			return ExecuteResult{Error: fmt.Errorf("failed to delete message: %w", err)}
+		}
+		return ExecuteResult{Output: "Message deleted."}
 	}
 
-	// Convert @username mentions to <@USER_ID> format
-	message = e.convertMentions(message)
-
-	_, err := e.client.PostMessage(e.currentChannel.ID, message)
-	if err != nil {
-		return ExecuteResult{Error: fmt.Errorf("failed to send message: %w", err)}
+	if cmd.GetFlagBool("yes") || cmd.GetFlagBool("y") {
+		return deleteTarget()
 	}
 
-	return ExecuteResult{Output: "Message sent."}
+	preview := strings.ReplaceAll(ConvertEmoji(ResolveMentions(target.Text, e.userNames)), "\n", " ")
+	return ExecuteResult{
+		Confirm: &ConfirmRequest{
+			Prompt: fmt.Sprintf("Delete \"%s\"? (y/N) ", truncatePreview(preview, 40)),
+			Action: deleteTarget,
+		},
+	}
 }
 
 // convertMentions converts @username patterns to Slack's <@USER_ID> format
@@ -495,7 +1596,7 @@ func (e *Executor) formatPrompt() string {
 			if name == "" {
 				name = e.currentChannel.UserID
 			}
-			location = "@" + name
+			location = "@" + name + presenceMarker(e.userPresence(e.currentChannel.UserID))
 			user = name
 		} else {
 			location = "#" + e.currentChannel.Name
@@ -503,40 +1604,51 @@ func (e *Executor) formatPrompt() string {
 		}
 	}
 
+	// Unread count comes from the notification manager, if any
+	var unread int
+	if e.notifyMgr != nil {
+		unread = e.notifyMgr.GetTotalUnread()
+	}
+
 	// Replace template variables
 	result := format
 	result = strings.ReplaceAll(result, "{workspace}", e.workspaceName)
 	result = strings.ReplaceAll(result, "{location}", location)
 	result = strings.ReplaceAll(result, "{channel}", channel)
 	result = strings.ReplaceAll(result, "{user}", user)
+	result = strings.ReplaceAll(result, "{unread}", strconv.Itoa(unread))
+	result = strings.ReplaceAll(result, "{time}", time.Now().Format("15:04"))
 
 	return result
 }
 
-func (e *Executor) executeSource(cmd Command) ExecuteResult {
-	if len(cmd.Args) == 0 {
-		return ExecuteResult{Output: "Usage: source <config-file-path>"}
-	}
-
-	path := cmd.Args[0]
-
-	// Expand ~ to home directory
-	if strings.HasPrefix(path, "~") {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return ExecuteResult{Error: fmt.Errorf("failed to get home directory: %w", err)}
-		}
-		path = filepath.Join(home, path[1:])
+// expandPath expands ~ and environment variables and makes path absolute
+func expandPath(path string) (string, error) {
+	path, err := platform.ExpandPath(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
 	}
 
 	// Make absolute path
 	if !filepath.IsAbs(path) {
 		cwd, err := os.Getwd()
 		if err != nil {
-			return ExecuteResult{Error: fmt.Errorf("failed to get current directory: %w", err)}
+			return "", fmt.Errorf("failed to get current directory: %w", err)
 		}
 		path = filepath.Join(cwd, path)
 	}
+	return path, nil
+}
+
+func (e *Executor) executeSource(cmd Command) ExecuteResult {
+	if len(cmd.Args) == 0 {
+		return ExecuteResult{Output: "Usage: source <config-file-path>"}
+	}
+
+	path, err := expandPath(cmd.Args[0])
+	if err != nil {
+		return ExecuteResult{Error: err}
+	}
 
 	// Load config from file
 	cfg, err := config.LoadFromPath(path)
@@ -592,6 +1704,7 @@ func (e *Executor) executeMkdir(cmd Command) ExecuteResult {
 
 	// Invalidate cache so the new channel shows up in ls
 	e.channels = nil
+	e.publicChannels = nil
 
 	prefix := "#"
 	if isPrivate {
@@ -618,6 +1731,143 @@ func (e *Executor) executeWhoami() ExecuteResult {
 	return ExecuteResult{Output: output.String()}
 }
 
+func (e *Executor) executeConnection() ExecuteResult {
+	var output strings.Builder
+
+	output.WriteString("Socket Mode:\n")
+	if e.hasAppToken {
+		output.WriteString("  App token:         configured\n")
+	} else {
+		output.WriteString("  App token:         not configured (set SLACK_APP_TOKEN to enable)\n")
+	}
+
+	if e.realtimeClient == nil {
+		output.WriteString("  Status:            not running\n")
+		return ExecuteResult{Output: output.String()}
+	}
+
+	now := time.Now()
+	lastConnected := e.realtimeClient.LastConnected()
+	lastDisconnected := e.realtimeClient.LastDisconnected()
+
+	switch {
+	case e.realtimeClient.Connected():
+		output.WriteString(fmt.Sprintf("  Status:            connected (up %s)\n", now.Sub(lastConnected).Round(time.Second)))
+	case lastConnected.IsZero():
+		output.WriteString("  Status:            connecting\n")
+	default:
+		output.WriteString(fmt.Sprintf("  Status:            disconnected (down %s)\n", now.Sub(lastDisconnected).Round(time.Second)))
+	}
+
+	output.WriteString(fmt.Sprintf("  Last connected:    %s\n", formatTransition(lastConnected)))
+	output.WriteString(fmt.Sprintf("  Last disconnected: %s\n", formatTransition(lastDisconnected)))
+
+	return ExecuteResult{Output: output.String()}
+}
+
+func (e *Executor) executeNotify(cmd Command) ExecuteResult {
+	if len(cmd.Args) == 0 || cmd.Args[0] != "test" {
+		return ExecuteResult{Output: "Usage: notify test"}
+	}
+	if e.notifyMgr == nil {
+		return ExecuteResult{Error: fmt.Errorf("notifications are not available in this mode")}
+	}
+
+	// No TeamID: a plain notification reports errors synchronously,
+	// whereas click-to-open notifiers run in the background
+	msg := notification.Message{
+		ChannelName: "slack-shell",
+		UserName:    e.client.GetUserName(),
+		Text:        "This is a test notification",
+		IsMention:   true,
+	}
+	if e.currentChannel != nil {
+		msg.ChannelID = e.currentChannel.ID
+		msg.ChannelName = e.currentChannel.Name
+		msg.IsIM = e.currentChannel.IsIM
+	}
+
+	var output strings.Builder
+	output.WriteString("Notification test:\n")
+	for _, r := range e.notifyMgr.Test(msg) {
+		status := "ok"
+		switch {
+		case !r.Enabled:
+			status = "disabled"
+		case r.Err != nil:
+			status = fmt.Sprintf("failed: %v", r.Err)
+		}
+		output.WriteString(fmt.Sprintf("  %-8s %s\n", r.Name, status))
+	}
+	if e.notifyMgr.IsDND() {
+		output.WriteString("\nDo Not Disturb is on: real notifications are currently suppressed.\n")
+	}
+
+	return ExecuteResult{Output: output.String()}
+}
+
+// formatTransition formats a connection transition time, or "never"
+func formatTransition(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+func (e *Executor) executeAuth(cmd Command) ExecuteResult {
+	if len(cmd.Args) == 0 || cmd.Args[0] != "status" {
+		return ExecuteResult{Output: "Usage: auth status"}
+	}
+
+	var output strings.Builder
+	output.WriteString("Auth Status:\n")
+
+	if err := e.client.AuthTest(); err != nil {
+		output.WriteString(fmt.Sprintf("  User token:  %s\n", describeAuthError(err)))
+	} else {
+		output.WriteString(fmt.Sprintf("  User token:  valid (@%s in %s)\n", e.client.GetUserName(), e.client.GetTeamName()))
+	}
+
+	if !e.client.HasBotToken() {
+		output.WriteString("  Bot token:   not configured (sudo app install needs channels:join)\n")
+	} else if err := e.client.BotAuthTest(); err != nil {
+		output.WriteString(fmt.Sprintf("  Bot token:   %s\n", describeAuthError(err)))
+	} else {
+		output.WriteString("  Bot token:   valid\n")
+	}
+
+	if e.hasAppToken {
+		output.WriteString("  App token:   configured\n")
+	} else {
+		output.WriteString("  App token:   not configured (live mode needs SLACK_APP_TOKEN)\n")
+	}
+
+	// Scopes are only known for tokens obtained through OAuth login
+	var creds *config.Credentials
+	if all, err := config.LoadCredentials(); err == nil {
+		creds = config.FindCredentials(all, e.client.GetTeamID())
+	}
+	if creds == nil || creds.Scope == "" {
+		output.WriteString("  Scopes:      unknown (token not from 'slack-shell login')\n")
+	} else {
+		output.WriteString(fmt.Sprintf("  Scopes:      %s\n", strings.ReplaceAll(creds.Scope, ",", ", ")))
+		if creds.BotScope != "" {
+			output.WriteString(fmt.Sprintf("  Bot scopes:  %s\n", strings.ReplaceAll(creds.BotScope, ",", ", ")))
+		}
+	}
+
+	return ExecuteResult{Output: output.String()}
+}
+
+// describeAuthError explains an auth.test failure
+func describeAuthError(err error) string {
+	switch err.Error() {
+	case "token_revoked", "token_expired", "invalid_auth", "account_inactive", "not_authed":
+		return fmt.Sprintf("invalid (%s): sign in again with 'slack-shell login'", err.Error())
+	}
+	return fmt.Sprintf("could not be checked: %v", err)
+}
+
 func (e *Executor) executeShow(cmd Command) ExecuteResult {
 	if e.currentChannel == nil {
 		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
@@ -644,9 +1894,63 @@ func (e *Executor) executeShow(cmd Command) ExecuteResult {
 	}
 
 	// Get channel members
-	memberIDs, err := e.client.GetChannelMembers(e.currentChannel.ID, memberLimit)
+	memberIDs, err := e.loadChannelMembers(memberLimit)
+	if err != nil {
+		return ExecuteResult{Error: err}
+	}
+
+	// Get creator name
+	creatorName := info.Creator
+	if info.Creator != "" {
+		if name, ok := e.userNames[info.Creator]; ok {
+			creatorName = name
+		} else {
+			user, err := e.client.GetUserInfo(info.Creator)
+			if err == nil && user != nil {
+				e.setUserFull(user.ID, user.Name, user.Profile.DisplayName, user.RealName)
+				creatorName = e.userNames[info.Creator]
+			}
+		}
+	}
+
+	return ExecuteResult{Output: FormatChannelInfo(info, memberIDs, e.userNames, creatorName, memberLimit, e.outputFormat)}
+}
+
+func (e *Executor) executeMembers(cmd Command) ExecuteResult {
+	if e.currentChannel == nil {
+		return ExecuteResult{Output: "Not in a channel. Use 'cd #channel' first."}
+	}
+
+	// Without -n, list every member (fetched page by page)
+	limit := cmd.GetFlagInt("n", 0)
+	if limit < 0 {
+		limit = 0
+	}
+
+	memberIDs, err := e.loadChannelMembers(limit)
 	if err != nil {
-		return ExecuteResult{Error: fmt.Errorf("failed to get channel members: %w", err)}
+		return ExecuteResult{Error: err}
+	}
+
+	return ExecuteResult{Output: FormatMembers(memberIDs, e.userNames, e.outputFormat)}
+}
+
+// loadChannelMembers returns up to limit member IDs of the current channel
+// (all of them if limit is 0) and resolves their user names. A DM's members
+// are its two participants.
+func (e *Executor) loadChannelMembers(limit int) ([]string, error) {
+	var memberIDs []string
+	if e.currentChannel.IsIM {
+		memberIDs = []string{e.client.GetUserID(), e.currentChannel.UserID}
+		if limit > 0 && limit < len(memberIDs) {
+			memberIDs = memberIDs[:limit]
+		}
+	} else {
+		var err error
+		memberIDs, err = e.client.GetChannelMembers(e.currentChannel.ID, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get channel members: %w", err)
+		}
 	}
 
 	// Load user names for members (only those not already cached)
@@ -666,21 +1970,35 @@ func (e *Executor) executeShow(cmd Command) ExecuteResult {
 		}
 	}
 
-	// Get creator name
-	creatorName := info.Creator
-	if info.Creator != "" {
-		if name, ok := e.userNames[info.Creator]; ok {
-			creatorName = name
-		} else {
-			user, err := e.client.GetUserInfo(info.Creator)
-			if err == nil && user != nil {
-				e.setUserFull(user.ID, user.Name, user.Profile.DisplayName, user.RealName)
-				creatorName = e.userNames[info.Creator]
-			}
-		}
+	return memberIDs, nil
+}
+
+// executeUsers searches the workspace directory: "users alice" or
+// "find -u alice"
+func (e *Executor) executeUsers(cmd Command) ExecuteResult {
+	query := strings.Join(cmd.Args, " ")
+	if v, ok := cmd.Flags["u"]; ok && v != "true" {
+		query = strings.TrimSpace(v + " " + query)
+	}
+	query = strings.TrimPrefix(query, "@")
+	if query == "" {
+		return ExecuteResult{Output: "Usage: users <name> or find -u <name>"}
+	}
+
+	limit := cmd.GetFlagInt("n", 20)
+	if limit <= 0 {
+		limit = 20
+	}
+
+	users, err := e.client.SearchUsers(query, limit)
+	if err != nil {
+		return ExecuteResult{Error: fmt.Errorf("failed to search users: %w", err)}
+	}
+	for _, u := range users {
+		e.setUserFull(u.ID, u.Name, u.DisplayName, u.RealName)
 	}
 
-	return ExecuteResult{Output: FormatChannelInfo(info, memberIDs, e.userNames, creatorName, memberLimit)}
+	return ExecuteResult{Output: FormatUsers(users, e.outputFormat)}
 }
 
 func (e *Executor) executeSudo(cmd Command) ExecuteResult {
@@ -875,11 +2193,14 @@ func (e *Executor) SwitchClient(client *slack.Client) {
 
 	e.client = client
 	e.channels = nil
+	e.publicChannels = nil
 	e.dms = nil
 	e.userNames = make(map[string]string)
 	e.userCache = nil    // Will be set by caller if needed
 	e.channelCache = nil // Will be set by caller if needed
 	e.currentChannel = nil
+	e.prevChannel = nil
+	e.channelStack = nil
 
 	// Update workspace name
 	e.workspaceName = "slack"
@@ -897,6 +2218,28 @@ func (e *Executor) SetUserCache(userCache *cache.UserCache) {
 	}
 }
 
+// SetNotificationManager sets the notification manager used for unread counts
+func (e *Executor) SetNotificationManager(notifyMgr *notification.Manager) {
+	e.notifyMgr = notifyMgr
+}
+
+// SetOutputFormat sets how commands such as ls, cat, members and show render
+// their output
+func (e *Executor) SetOutputFormat(format OutputFormat) {
+	e.outputFormat = format
+}
+
+// SetRealtimeClient sets the Socket Mode client reported by the connection
+// command
+func (e *Executor) SetRealtimeClient(rc *slack.RealtimeClient) {
+	e.realtimeClient = rc
+}
+
+// SetCompletionConfig sets the tab completion settings
+func (e *Executor) SetCompletionConfig(completionConfig *config.CompletionConfig) {
+	e.completion = completionConfig
+}
+
 // SetChannelCache sets the channel cache (used when switching workspaces)
 func (e *Executor) SetChannelCache(channelCache *cache.ChannelCache) {
 	e.channelCache = channelCache
@@ -1077,6 +2420,52 @@ func getCommandName(t CommandType) string {
 		return "whoami"
 	case CmdShow:
 		return "show"
+	case CmdEdit:
+		return "edit"
+	case CmdRm:
+		return "rm"
+	case CmdReply:
+		return "reply"
+	case CmdPin:
+		return "pin"
+	case CmdUnpin:
+		return "unpin"
+	case CmdPins:
+		return "pins"
+	case CmdStatus:
+		return "status"
+	case CmdExport:
+		return "export"
+	case CmdMembers:
+		return "members"
+	case CmdThread:
+		return "thread"
+	case CmdRefresh:
+		return "refresh"
+	case CmdMute:
+		return "mute"
+	case CmdUnmute:
+		return "unmute"
+	case CmdDnd:
+		return "dnd"
+	case CmdConnection:
+		return "connection"
+	case CmdNotify:
+		return "notify"
+	case CmdAuth:
+		return "auth"
+	case CmdUsers:
+		return "users"
+	case CmdPushd:
+		return "pushd"
+	case CmdPopd:
+		return "popd"
+	case CmdDirs:
+		return "dirs"
+	case CmdLink:
+		return "link"
+	case CmdOpen:
+		return "open"
 	default:
 		return "unknown"
 	}
@@ -1127,20 +2516,7 @@ func (e *Executor) GetCurrentUserID() string {
 
 // IsMentionedInMessage checks if the current user is mentioned in the message
 func (e *Executor) IsMentionedInMessage(text string) bool {
-	// Check for @here, @channel, @everyone
-	if strings.Contains(text, "<!here>") ||
-		strings.Contains(text, "<!channel>") ||
-		strings.Contains(text, "<!everyone>") {
-		return true
-	}
-
-	// Check for direct mention (<@USER_ID>)
-	currentUserID := e.client.GetUserID()
-	if currentUserID != "" && strings.Contains(text, fmt.Sprintf("<@%s>", currentUserID)) {
-		return true
-	}
-
-	return false
+	return mentionsUser(text, e.client.GetUserID())
 }
 
 // IsIMChannel checks if a channel ID is a direct message channel
@@ -1188,7 +2564,8 @@ func (e *Executor) GetCompletions(prefix string) []string {
 		}
 	}
 
-	var candidates []string
+	var candidates []completionCandidate
+	fuzzy := e.completion != nil && e.completion.Fuzzy
 
 	// Determine what to complete based on prefix
 	showChannels := true
@@ -1208,9 +2585,8 @@ func (e *Executor) GetCompletions(prefix string) []string {
 	// Add channel candidates
 	if showChannels && e.channels != nil {
 		for _, ch := range e.channels {
-			name := "#" + ch.Name
-			if searchTerm == "" || strings.HasPrefix(strings.ToLower(ch.Name), searchTerm) {
-				candidates = append(candidates, name)
+			if score, ok := completionMatch(ch.Name, searchTerm, fuzzy); ok {
+				candidates = append(candidates, completionCandidate{text: "#" + ch.Name, score: score})
 			}
 		}
 	}
@@ -1222,33 +2598,57 @@ func (e *Executor) GetCompletions(prefix string) []string {
 			if userName == "" {
 				continue
 			}
-			name := "@" + userName
-			if searchTerm == "" || strings.HasPrefix(strings.ToLower(userName), searchTerm) {
-				candidates = append(candidates, name)
+			if score, ok := completionMatch(userName, searchTerm, fuzzy); ok {
+				candidates = append(candidates, completionCandidate{text: "@" + userName, score: score})
 			}
 		}
 	}
 
-	return candidates
+	return rankCompletions(candidates)
 }
 
 // availableCommands is the list of all shell commands for tab completion
 var availableCommands = []string{
+	"auth",
 	"browse",
 	"cat",
 	"cd",
+	"connection",
+	"dirs",
+	"dnd",
+	"edit",
 	"exit",
+	"export",
+	"find",
 	"grep",
 	"help",
+	"link",
 	"live",
 	"ls",
+	"members",
 	"mkdir",
+	"mute",
+	"notify",
+	"open",
+	"pin",
+	"pins",
+	"popd",
+	"pushd",
 	"pwd",
 	"quit",
+	"refresh",
+	"reply",
+	"rm",
 	"send",
 	"show",
 	"source",
+	"status",
 	"sudo",
+	"thread",
+	"unmute",
+	"unpin",
+	"uptime",
+	"users",
 	"version",
 	"whoami",
 }
@@ -1267,8 +2667,21 @@ func (e *Executor) GetCommandCompletions(prefix string) []string {
 
 // GetArgumentCompletions returns completion candidates based on command context
 func (e *Executor) GetArgumentCompletions(cmd string, argPrefix string) []string {
+	// Complete an emoji shortcode in the word being typed (e.g. "send hi :sm")
+	head, word := "", argPrefix
+	if i := strings.LastIndex(argPrefix, " "); i >= 0 {
+		head, word = argPrefix[:i+1], argPrefix[i+1:]
+	}
+	if strings.HasPrefix(word, ":") {
+		var candidates []string
+		for _, name := range EmojiCompletions(word) {
+			candidates = append(candidates, head+name)
+		}
+		return candidates
+	}
+
 	switch cmd {
-	case "cd":
+	case "cd", "pushd", "open":
 		return e.GetCompletions(argPrefix)
 	case "cat", "browse", "mkdir", "live":
 		// These commands also work with channels
@@ -0,0 +1,71 @@
+package shell
+
+import (
+	"sort"
+	"strings"
+)
+
+// completionCandidate is a completion string with its match score
+type completionCandidate struct {
+	text  string
+	score int
+}
+
+// completionMatch reports whether name matches query and how well.
+// Lower scores are better: prefix matches score 0, then (with fuzzy enabled)
+// substring matches, then subsequence matches ranked by how spread out the
+// matched characters are.
+func completionMatch(name, query string, fuzzy bool) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+
+	name = strings.ToLower(name)
+	query = strings.ToLower(query)
+
+	if strings.HasPrefix(name, query) {
+		return 0, true
+	}
+	if !fuzzy {
+		return 0, false
+	}
+
+	if idx := strings.Index(name, query); idx >= 0 {
+		return 1 + idx, true
+	}
+
+	// Subsequence match: every query rune appears in order
+	nameRunes := []rune(name)
+	pos := 0
+	gaps := 0
+	for _, q := range query {
+		found := false
+		for pos < len(nameRunes) {
+			r := nameRunes[pos]
+			pos++
+			if r == q {
+				found = true
+				break
+			}
+			gaps++
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return 1000 + gaps, true
+}
+
+// rankCompletions orders candidates by score, keeping the original order
+// for equal scores
+func rankCompletions(candidates []completionCandidate) []string {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score < candidates[j].score
+	})
+
+	result := make([]string, len(candidates))
+	for i, c := range candidates {
+		result[i] = c.text
+	}
+	return result
+}
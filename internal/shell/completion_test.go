@@ -0,0 +1,156 @@
+package shell
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/polidog/slack-shell/internal/cache"
+)
+
+func TestCompletionMatch(t *testing.T) {
+	tests := []struct {
+		name, query string
+		fuzzy       bool
+		wantOK      bool
+	}{
+		{"general", "", false, true},
+		{"general", "gen", false, true},
+		{"general", "GEN", false, true},
+		{"general", "enl", false, false},
+		{"general", "enl", true, true},
+		{"general", "ner", true, true},
+		{"general", "xyz", true, false},
+		{"general", "generals", true, false},
+	}
+
+	for _, tt := range tests {
+		_, ok := completionMatch(tt.name, tt.query, tt.fuzzy)
+		if ok != tt.wantOK {
+			t.Errorf("completionMatch(%q, %q, %v) ok = %v; want %v", tt.name, tt.query, tt.fuzzy, ok, tt.wantOK)
+		}
+	}
+}
+
+func TestRankCompletions(t *testing.T) {
+	names := []string{"dev-general", "gnrl-archive", "general", "g-e-n-e-r-a-l", "random"}
+
+	var candidates []completionCandidate
+	for _, name := range names {
+		if score, ok := completionMatch(name, "gen", true); ok {
+			candidates = append(candidates, completionCandidate{text: name, score: score})
+		}
+	}
+
+	// Prefix first, then substring, then tighter subsequences before looser ones
+	want := []string{"general", "dev-general", "g-e-n-e-r-a-l"}
+	if got := rankCompletions(candidates); !reflect.DeepEqual(got, want) {
+		t.Errorf("rankCompletions = %v; want %v", got, want)
+	}
+
+	// Equal scores keep their original order
+	candidates = []completionCandidate{{"b", 0}, {"a", 0}, {"c", 0}}
+	if got := rankCompletions(candidates); !reflect.DeepEqual(got, []string{"b", "a", "c"}) {
+		t.Errorf("rankCompletions should be stable, got %v", got)
+	}
+}
+
+func TestMatchMentionCandidates(t *testing.T) {
+	members := []string{"U1", "U2", "U3", "U4"}
+	profiles := map[string]cache.CachedUser{
+		"U1": {Name: "jsmith", DisplayName: "Johnny", RealName: "John Smith"},
+		"U2": {Name: "akiko.t", DisplayName: "", RealName: "Tanaka Akiko"},
+		"U3": {Name: "bob", DisplayName: "Robert", RealName: "Robert Jones"},
+	}
+	// U4 has no profile and only matches by its shown name
+	userNames := map[string]string{"U1": "Johnny", "U2": "akiko.t", "U3": "Robert", "U4": "ghost"}
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"", []string{"U1", "U2", "U3", "U4"}},
+		{"jsm", []string{"U1"}},    // username
+		{"john", []string{"U1"}},   // display and real name
+		{"tanaka", []string{"U2"}}, // real name only
+		{"bo", []string{"U3"}},     // username differs from display name
+		{"rob", []string{"U3"}},    // display name
+		{"gho", []string{"U4"}},    // shown name without profile
+		{"smith", nil},             // not a prefix of any field
+		{"zzz", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, c := range matchMentionCandidates(members, profiles, userNames, tt.prefix, 10) {
+			got = append(got, c.UserID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("matchMentionCandidates(%q) = %v; want %v", tt.prefix, got, tt.want)
+		}
+	}
+
+	// Special mentions come first when the prefix matches them
+	got := matchMentionCandidates(members, profiles, userNames, "ch", 10)
+	if want := []mentionCandidate{{UserName: "channel"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("matchMentionCandidates(\"ch\") = %v; want %v", got, want)
+	}
+
+	// Candidates are labeled with the shown name and capped at limit
+	got = matchMentionCandidates(members, profiles, userNames, "", 2)
+	want := []mentionCandidate{{UserID: "U1", UserName: "Johnny"}, {UserID: "U2", UserName: "akiko.t"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("matchMentionCandidates limit = %v; want %v", got, want)
+	}
+}
+
+func TestEncodeSpecialMentions(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"@here deploy is done", "<!here> deploy is done"},
+		{"ping @channel, @everyone", "ping <!channel>, <!everyone>"},
+		{"line one\n@here", "line one\n<!here>"},
+		{"(@channel)", "(<!channel>)"},
+		{"mail me@here.com", "mail me@here.com"},
+		{"@hereafter and @channels", "@hereafter and @channels"},
+		{"<@U123> hi", "<@U123> hi"},
+	}
+	for _, tt := range tests {
+		if got := encodeSpecialMentions(tt.in); got != tt.want {
+			t.Errorf("encodeSpecialMentions(%q) = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeUserMentions(t *testing.T) {
+	profiles := map[string]cache.CachedUser{
+		"U1": {Name: "alice", DisplayName: "Ali", RealName: "Alice Smith"},
+		"U2": {Name: "bob.jones", DisplayName: "Bob", RealName: "Bob Jones"},
+		"U3": {Name: "bobby", DisplayName: "Bob", RealName: "Bobby Tables"},
+		"U5": {Name: "taro", DisplayName: "太郎", RealName: "山田 太郎"},
+	}
+	userNames := map[string]string{"U1": "Ali", "U2": "Bob", "U3": "Bob", "U4": "carol", "U5": "太郎"}
+	handles := mentionHandles(profiles, userNames)
+	if _, ok := handles["alice smith"]; ok {
+		t.Errorf("mentionHandles kept %q, which can't be typed as a mention", "alice smith")
+	}
+
+	tests := []struct {
+		in, want string
+	}{
+		{"@alice hi", "<@U1> hi"},                    // username
+		{"hi @Ali", "hi <@U1>"},                      // display name, any case
+		{"@bob.jones and @bobby", "<@U2> and <@U3>"}, // usernames with dots
+		{"thanks @alice.", "thanks <@U1>."},          // trailing period
+		{"(@carol)", "(<@U4>)"},                      // shown name without profile
+		{"@太郎 こんにちは", "<@U5> こんにちは"},                 // non-ASCII display name
+		{"@bob what", "@bob what"},                   // ambiguous display name
+		{"@nobody there", "@nobody there"},           // unknown
+		{"me@alice.com", "me@alice.com"},             // not a word start
+		{"<@U1> already", "<@U1> already"},
+	}
+	for _, tt := range tests {
+		if got := encodeUserMentions(tt.in, handles); got != tt.want {
+			t.Errorf("encodeUserMentions(%q) = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package shell
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// highlightTerm is text to emphasize within a rendered line
+type highlightTerm struct {
+	lower []rune // the text, lowercased for matching
+	style lipgloss.Style
+	// wholeWord requires a word boundary after the match, so "@bob" doesn't
+	// highlight the start of "@bobby"
+	wholeWord bool
+}
+
+// newHighlightTerm creates a term matching text case-insensitively
+func newHighlightTerm(text string, style lipgloss.Style, wholeWord bool) highlightTerm {
+	return highlightTerm{lower: []rune(strings.ToLower(text)), style: style, wholeWord: wholeWord}
+}
+
+// mentionTerms returns the highlights for mentions of the user named
+// selfName, including @here, @channel and @everyone
+func mentionTerms(selfName string) []highlightTerm {
+	style := mentionHighlightStyle
+	terms := []highlightTerm{
+		newHighlightTerm("@here", style, true),
+		newHighlightTerm("@channel", style, true),
+		newHighlightTerm("@everyone", style, true),
+	}
+	if selfName != "" {
+		terms = append(terms, newHighlightTerm("@"+selfName, style, true))
+	}
+	return terms
+}
+
+// isNameRune reports whether r can continue a user name
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-'
+}
+
+// renderHighlighted renders line with base, except for runs matching one of
+// terms (case-insensitive), which get the term's style layered on base.
+// Each run is rendered separately so a selected row keeps its background
+// across highlights. Earlier terms win when matches overlap.
+func renderHighlighted(line string, base lipgloss.Style, terms []highlightTerm) string {
+	runes := []rune(line)
+	lower := []rune(strings.Map(unicode.ToLower, line))
+	if len(lower) != len(runes) {
+		return base.Render(line)
+	}
+
+	var sb strings.Builder
+	start := 0
+	for i := 0; i < len(runes); {
+		matched := false
+		for _, term := range terms {
+			t := term.lower
+			if len(t) == 0 || i+len(t) > len(lower) || !slices.Equal(lower[i:i+len(t)], t) {
+				continue
+			}
+			if end := i + len(t); term.wholeWord && end < len(lower) && isNameRune(lower[end]) {
+				// A trailing "." ends a sentence rather than the name
+				if lower[end] != '.' || (end+1 < len(lower) && isNameRune(lower[end+1])) {
+					continue
+				}
+			}
+			if start < i {
+				sb.WriteString(base.Render(string(runes[start:i])))
+			}
+			sb.WriteString(term.style.Inherit(base).Render(string(runes[i : i+len(t)])))
+			i += len(t)
+			start = i
+			matched = true
+			break
+		}
+		if !matched {
+			i++
+		}
+	}
+	if start < len(runes) {
+		sb.WriteString(base.Render(string(runes[start:])))
+	}
+	return sb.String()
+}
@@ -1,47 +1,23 @@
 package shell
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/polidog/slack-shell/internal/cache"
 	"github.com/polidog/slack-shell/internal/config"
+	"github.com/polidog/slack-shell/internal/keymap"
+	"github.com/polidog/slack-shell/internal/notification"
 	"github.com/polidog/slack-shell/internal/slack"
 )
 
-var (
-	liveSelectedStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("4")).
-				Foreground(lipgloss.Color("15"))
-	liveNormalStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
-	liveThreadStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("6")).
-			PaddingLeft(2)
-	liveHeaderStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("3")).
-			Bold(true)
-	liveHelpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("8"))
-	liveNewMsgStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("2"))
-	liveNotifyBarStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("15")).
-				Background(lipgloss.Color("8"))
-	liveNotifyPanelStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("6")).
-				Padding(0, 1)
-	livePeekHeaderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("5")).
-				Bold(true)
-)
-
 // InputMode represents the type of input in live mode
 type InputMode int
 
@@ -61,6 +37,9 @@ type LiveModel struct {
 	width, height int
 	userCache     map[string]string
 	displayConfig *config.DisplayConfig
+	keymap        *keymap.Keymap
+	keywords      *notification.KeywordMatcher
+	selfName      string // highlighted as @selfName in messages
 
 	// Thread display
 	threadMessages []slack.Message
@@ -85,16 +64,37 @@ type LiveModel struct {
 	// Delete confirmation
 	deleteConfirm bool
 
+	// Permalink of the selected message, shown after pressing Y
+	permalink string
+
+	// Users typing in the channel, by the time their last typing event
+	// arrived. Stale entries are dropped when rendering.
+	typing map[string]time.Time
+
+	// Result of copying the selected message with y: "Copied", or the text
+	// itself when no clipboard is available
+	yankNote string
+	yankText string
+
 	// Edit mode
 	editTS string
 
+	// Unsent messages by draft key (see draftKey), shared with the shell so
+	// they survive leaving live mode
+	drafts map[string]string
+
 	// Mention completion
 	mentionActive     bool
 	mentionCandidates []mentionCandidate
 	mentionIndex      int
 	mentionPrefix     string // The text after @ being completed
 	channelMembers    []string
+	memberProfiles    map[string]cache.CachedUser // userID -> all name fields
 	membersLoaded     bool
+	profileCache      *cache.UserCache // persistent user cache, may be nil
+
+	// Message search
+	messageSearch
 
 	// Notification display
 	notifications     []NotificationItem
@@ -123,10 +123,76 @@ type LiveModel struct {
 
 // mentionCandidate represents a user mention candidate
 type mentionCandidate struct {
-	UserID   string
+	UserID   string // empty for special mentions like @here
 	UserName string
 }
 
+// specialMentions are the broadcast mentions offered in completion
+var specialMentions = []string{"here", "channel", "everyone"}
+
+// specialMentionPattern matches @here, @channel and @everyone typed as words
+var specialMentionPattern = regexp.MustCompile(`(^|[\s(])@(here|channel|everyone)\b`)
+
+// encodeSpecialMentions converts @here, @channel and @everyone into Slack's
+// <!here> syntax so they notify the channel instead of sending plain text
+func encodeSpecialMentions(text string) string {
+	return specialMentionPattern.ReplaceAllString(text, "$1<!$2>")
+}
+
+// userMentionPattern matches @name typed as a word
+var userMentionPattern = regexp.MustCompile(`(^|[\s(])@([\p{L}\p{N}_.\-]+)`)
+
+// mentionNamePattern matches names userMentionPattern can capture
+var mentionNamePattern = regexp.MustCompile(`^[\p{L}\p{N}_.\-]*[\p{L}\p{N}_\-]$`)
+
+// encodeUserMentions converts @name into Slack's <@USERID> syntax using
+// handles (lowercase name -> user ID) so the user is notified. Trailing
+// periods are treated as punctuation. Unknown names are left as written.
+func encodeUserMentions(text string, handles map[string]string) string {
+	return userMentionPattern.ReplaceAllStringFunc(text, func(match string) string {
+		sub := userMentionPattern.FindStringSubmatch(match)
+		name := strings.TrimRight(sub[2], ".")
+		userID, ok := handles[strings.ToLower(name)]
+		if !ok || userID == "" {
+			return match
+		}
+		return sub[1] + "<@" + userID + ">" + sub[2][len(name):]
+	})
+}
+
+// mentionHandles maps the names a user can be mentioned by (username,
+// display name, real name and shown name, lowercased) to user IDs. Names
+// that can't be typed as a mention, such as ones with spaces, are left out,
+// and names shared by different users map to "" so they aren't guessed.
+func mentionHandles(profiles map[string]cache.CachedUser, userNames map[string]string) map[string]string {
+	handles := make(map[string]string)
+	add := func(name, userID string) {
+		if !mentionNamePattern.MatchString(name) {
+			return
+		}
+		key := strings.ToLower(name)
+		if existing, ok := handles[key]; ok && existing != userID {
+			handles[key] = ""
+			return
+		}
+		handles[key] = userID
+	}
+	for userID, name := range userNames {
+		add(name, userID)
+	}
+	for userID, profile := range profiles {
+		add(profile.Name, userID)
+		add(profile.DisplayName, userID)
+		add(profile.RealName, userID)
+	}
+	return handles
+}
+
+// encodeMentions converts typed mentions into Slack syntax before sending
+func (m *LiveModel) encodeMentions(text string) string {
+	return encodeUserMentions(encodeSpecialMentions(text), mentionHandles(m.memberProfiles, m.userCache))
+}
+
 // NotificationItem represents a notification from another channel
 type NotificationItem struct {
 	ChannelID   string
@@ -138,7 +204,7 @@ type NotificationItem struct {
 }
 
 // NewLiveModel creates a new LiveModel
-func NewLiveModel(client *slack.Client, channelID, channelName string, userCache map[string]string, displayConfig *config.DisplayConfig) *LiveModel {
+func NewLiveModel(client *slack.Client, channelID, channelName string, userCache map[string]string, displayConfig *config.DisplayConfig, km *keymap.Keymap) *LiveModel {
 	ta := textarea.New()
 	ta.Placeholder = "Type a message..."
 	ta.CharLimit = 4000
@@ -149,6 +215,9 @@ func NewLiveModel(client *slack.Client, channelID, channelName string, userCache
 	if displayConfig == nil {
 		displayConfig = config.DefaultDisplayConfig()
 	}
+	if km == nil {
+		km = keymap.New(nil)
+	}
 
 	return &LiveModel{
 		client:        client,
@@ -156,8 +225,10 @@ func NewLiveModel(client *slack.Client, channelID, channelName string, userCache
 		channelName:   channelName,
 		userCache:     userCache,
 		displayConfig: displayConfig,
+		keymap:        km,
 		inputText:     ta,
 		loading:       true,
+		drafts:        make(map[string]string),
 	}
 }
 
@@ -183,11 +254,15 @@ type LiveThreadLoadedMsg struct {
 // LiveMessageSentMsg is sent when a message is sent in live mode
 type LiveMessageSentMsg struct {
 	Err error
+
+	draftKey, text string
 }
 
 // LiveReplySentMsg is sent when a reply is sent in live mode
 type LiveReplySentMsg struct {
 	Err error
+
+	draftKey, text string
 }
 
 // LiveOlderMessagesLoadedMsg is sent when older messages are loaded
@@ -203,6 +278,26 @@ type LiveMessageDeletedMsg struct {
 	Err       error
 }
 
+// LivePermalinkMsg is sent when a message's permalink is loaded
+type LivePermalinkMsg struct {
+	URL string
+	Err error
+}
+
+// LiveTypingExpiredMsg is sent when a typing indicator may have expired, so
+// the view is redrawn without it
+type LiveTypingExpiredMsg struct{}
+
+// typingTimeout is how long a user is shown as typing after their last
+// typing event
+const typingTimeout = 5 * time.Second
+
+// LiveYankedMsg is sent when a message's text has been copied
+type LiveYankedMsg struct {
+	Text string
+	Err  error
+}
+
 // LiveMessageEditedMsg is sent when a message is edited
 type LiveMessageEditedMsg struct {
 	Timestamp string
@@ -269,16 +364,74 @@ func (m *LiveModel) loadThread(threadTS string) tea.Cmd {
 }
 
 func (m *LiveModel) sendMessage(text string) tea.Cmd {
+	outgoing := m.encodeMentions(text)
 	return func() tea.Msg {
-		_, err := m.client.PostMessage(m.channelID, text)
-		return LiveMessageSentMsg{Err: err}
+		_, err := m.client.PostMessage(m.channelID, outgoing)
+		return LiveMessageSentMsg{Err: err, draftKey: m.channelID, text: text}
+	}
+}
+
+// draftKey identifies the message being typed: the channel for a new
+// message, or channel:thread for a reply. Edits aren't kept as drafts.
+func (m *LiveModel) draftKey() string {
+	switch m.inputMode {
+	case InputModeNewMessage:
+		return m.channelID
+	case InputModeReply:
+		return m.channelID + ":" + m.threadTS
+	}
+	return ""
+}
+
+// saveDraft keeps the text being typed so it can be restored later
+func (m *LiveModel) saveDraft() {
+	key := m.draftKey()
+	if key == "" {
+		return
+	}
+	if strings.TrimSpace(m.inputText.Value()) == "" {
+		delete(m.drafts, key)
+		return
+	}
+	m.drafts[key] = m.inputText.Value()
+}
+
+// restoreDraft puts back the saved text for the current input mode
+func (m *LiveModel) restoreDraft() {
+	if key := m.draftKey(); key != "" {
+		m.inputText.SetValue(m.drafts[key])
+	}
+}
+
+// clearSentDraft drops a draft once its text was sent, unless it has been
+// changed since
+func (m *LiveModel) clearSentDraft(key, text string) {
+	if strings.TrimSpace(m.drafts[key]) == text {
+		delete(m.drafts, key)
 	}
 }
 
 func (m *LiveModel) sendReply(threadTS, text string) tea.Cmd {
+	outgoing := m.encodeMentions(text)
+	return func() tea.Msg {
+		_, err := m.client.PostThreadReply(m.channelID, threadTS, outgoing)
+		return LiveReplySentMsg{Err: err, draftKey: m.channelID + ":" + threadTS, text: text}
+	}
+}
+
+func (m *LiveModel) loadPermalink(timestamp string) tea.Cmd {
+	return func() tea.Msg {
+		url, err := m.client.GetPermalink(m.channelID, timestamp)
+		return LivePermalinkMsg{URL: url, Err: err}
+	}
+}
+
+// yankMessage copies text to the system clipboard, with user mentions
+// resolved to names
+func (m *LiveModel) yankMessage(text string) tea.Cmd {
+	text = ResolveMentions(text, m.userCache)
 	return func() tea.Msg {
-		_, err := m.client.PostThreadReply(m.channelID, threadTS, text)
-		return LiveReplySentMsg{Err: err}
+		return LiveYankedMsg{Text: text, Err: copyToClipboard(text)}
 	}
 }
 
@@ -299,7 +452,8 @@ func (m *LiveModel) editMessage(timestamp, text string) tea.Cmd {
 // LiveMembersLoadedMsg is sent when channel members are loaded
 type LiveMembersLoadedMsg struct {
 	Members   []string
-	UserNames map[string]string // userID -> userName
+	UserNames map[string]string           // userID -> userName
+	Profiles  map[string]cache.CachedUser // userID -> all name fields
 	Err       error
 }
 
@@ -315,12 +469,18 @@ func (m *LiveModel) loadChannelMembers() tea.Cmd {
 		for k, v := range m.userCache {
 			userNames[k] = v
 		}
-		// Find uncached users
+		// Mention completion matches every name field, so look up full
+		// profiles rather than just the preferred names
+		profiles := make(map[string]cache.CachedUser)
 		var uncached []string
 		for _, userID := range members {
-			if _, ok := userNames[userID]; !ok {
-				uncached = append(uncached, userID)
+			if m.profileCache != nil {
+				if entry, ok := m.profileCache.GetFull(userID); ok {
+					profiles[userID] = entry
+					continue
+				}
 			}
+			uncached = append(uncached, userID)
 		}
 		// Fetch uncached users
 		if len(uncached) > 0 {
@@ -332,11 +492,15 @@ func (m *LiveModel) loadChannelMembers() tea.Cmd {
 						DisplayName: u.Profile.DisplayName,
 						RealName:    u.RealName,
 					}
+					profiles[u.ID] = entry
 					userNames[u.ID] = entry.GetPreferredName(m.displayConfig.NameFormat)
+					if m.profileCache != nil {
+						m.profileCache.SetFull(u.ID, u.Name, u.Profile.DisplayName, u.RealName)
+					}
 				}
 			}
 		}
-		return LiveMembersLoadedMsg{Members: members, UserNames: userNames, Err: nil}
+		return LiveMembersLoadedMsg{Members: members, UserNames: userNames, Profiles: profiles, Err: nil}
 	}
 }
 
@@ -388,30 +552,46 @@ func (m *LiveModel) updateMentionCompletion() {
 	}
 	m.mentionPrefix = strings.ToLower(prefix)
 
-	// Build candidates from channel members
-	m.mentionCandidates = nil
-	for _, userID := range m.channelMembers {
-		userName, ok := m.userCache[userID]
+	// Build candidates from channel members (limited to 10)
+	m.mentionCandidates = matchMentionCandidates(m.channelMembers, m.memberProfiles, m.userCache, m.mentionPrefix, 10)
+
+	m.mentionActive = len(m.mentionCandidates) > 0
+	if m.mentionActive && m.mentionIndex >= len(m.mentionCandidates) {
+		m.mentionIndex = 0
+	}
+}
+
+// matchMentionCandidates returns up to limit members whose username,
+// display name, real name or shown name starts with prefix (lowercase).
+// Special mentions (@here, @channel, @everyone) matching prefix come first.
+// Candidates are listed by their shown name from userNames.
+func matchMentionCandidates(members []string, profiles map[string]cache.CachedUser, userNames map[string]string, prefix string, limit int) []mentionCandidate {
+	var candidates []mentionCandidate
+	for _, special := range specialMentions {
+		if prefix != "" && strings.HasPrefix(special, prefix) {
+			candidates = append(candidates, mentionCandidate{UserName: special})
+		}
+	}
+	for _, userID := range members {
+		userName, ok := userNames[userID]
 		if !ok {
 			continue
 		}
-		// Filter by prefix
-		if m.mentionPrefix == "" || strings.HasPrefix(strings.ToLower(userName), m.mentionPrefix) {
-			m.mentionCandidates = append(m.mentionCandidates, mentionCandidate{
-				UserID:   userID,
-				UserName: userName,
-			})
+		names := []string{userName}
+		if profile, ok := profiles[userID]; ok {
+			names = append(names, profile.Name, profile.DisplayName, profile.RealName)
+		}
+		for _, name := range names {
+			if name != "" && strings.HasPrefix(strings.ToLower(name), prefix) {
+				candidates = append(candidates, mentionCandidate{UserID: userID, UserName: userName})
+				break
+			}
 		}
-		// Limit to 10 candidates
-		if len(m.mentionCandidates) >= 10 {
+		if len(candidates) >= limit {
 			break
 		}
 	}
-
-	m.mentionActive = len(m.mentionCandidates) > 0
-	if m.mentionActive && m.mentionIndex >= len(m.mentionCandidates) {
-		m.mentionIndex = 0
-	}
+	return candidates
 }
 
 // completeMention inserts the selected mention candidate
@@ -440,8 +620,12 @@ func (m *LiveModel) completeMention() {
 		return
 	}
 
-	// Replace @prefix with <@USER_ID> format for Slack mention
+	// Replace @prefix with <@USER_ID> format for Slack mention. Special
+	// mentions stay readable and are encoded when sent.
 	newText := string(runes[:mentionStart]) + "<@" + candidate.UserID + "> "
+	if candidate.UserID == "" {
+		newText = string(runes[:mentionStart]) + "@" + candidate.UserName + " "
+	}
 
 	m.inputText.SetValue(newText)
 	// Move cursor to end
@@ -500,6 +684,8 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 	case LiveMessageSentMsg:
 		if msg.Err != nil {
 			m.loadingErr = msg.Err
+		} else {
+			m.clearSentDraft(msg.draftKey, msg.text)
 		}
 		// Message will appear via real-time events
 		return m, nil
@@ -508,6 +694,7 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 		if msg.Err != nil {
 			m.loadingErr = msg.Err
 		} else {
+			m.clearSentDraft(msg.draftKey, msg.text)
 			// Reload thread to show the new reply
 			return m, m.loadThread(m.threadTS)
 		}
@@ -531,6 +718,30 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 		}
 		return m, nil
 
+	case LivePermalinkMsg:
+		if msg.Err != nil {
+			m.permalink = fmt.Sprintf("Could not get link: %v", msg.Err)
+		} else {
+			m.permalink = msg.URL
+		}
+		return m, nil
+
+	case LiveTypingExpiredMsg:
+		return m, nil
+
+	case LiveYankedMsg:
+		switch {
+		case msg.Err == nil:
+			m.yankNote = "Copied"
+		case errors.Is(msg.Err, errNoClipboard):
+			m.yankNote = "No clipboard available"
+			m.yankText = msg.Text
+		default:
+			m.yankNote = fmt.Sprintf("Could not copy: %v", msg.Err)
+			m.yankText = msg.Text
+		}
+		return m, nil
+
 	case LiveMessageEditedMsg:
 		if msg.Err != nil {
 			m.loadingErr = msg.Err
@@ -550,6 +761,7 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 			m.loadingErr = msg.Err
 		} else {
 			m.channelMembers = msg.Members
+			m.memberProfiles = msg.Profiles
 			// Merge user names into cache
 			for k, v := range msg.UserNames {
 				m.userCache[k] = v
@@ -650,6 +862,8 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 				}
 				return m, nil
 			case tea.KeyEsc:
+				// Keep what was typed; it comes back the next time
+				m.saveDraft()
 				m.inputMode = InputModeNone
 				m.editTS = ""
 				m.mentionActive = false
@@ -664,6 +878,8 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 					// Note: Bubble Tea represents shift+enter differently
 					text := strings.TrimSpace(m.inputText.Value())
 					if text != "" {
+						// Kept until the send succeeds
+						m.saveDraft()
 						currentMode := m.inputMode
 						editTS := m.editTS
 						m.inputMode = InputModeNone
@@ -688,6 +904,8 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 				if sendKey == "ctrl+enter" {
 					text := strings.TrimSpace(m.inputText.Value())
 					if text != "" {
+						// Kept until the send succeeds
+						m.saveDraft()
 						currentMode := m.inputMode
 						editTS := m.editTS
 						m.inputMode = InputModeNone
@@ -729,6 +947,12 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 			}
 		}
 
+		// Handle search query input
+		if m.searchMode {
+			m.selectMessage(m.handleSearchKey(msg, m.messages, m.userCache))
+			return m, nil
+		}
+
 		// Handle delete confirmation
 		if m.deleteConfirm {
 			switch msg.String() {
@@ -748,17 +972,18 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 
 		// Handle thread view
 		if m.threadVisible {
-			switch msg.String() {
-			case "q", "esc":
+			switch {
+			case m.keymap.MatchKey(msg, keymap.ActionCloseThread):
 				m.threadVisible = false
 				m.threadMessages = nil
 				m.threadTS = ""
 				return m, nil
-			case "r":
+			case m.keymap.MatchKey(msg, keymap.ActionReply):
 				if m.threadTS != "" {
 					m.inputMode = InputModeReply
 					m.inputText.Placeholder = "Type your reply..."
 					m.inputText.Focus()
+					m.restoreDraft()
 					return m, textarea.Blink
 				}
 				return m, nil
@@ -766,12 +991,34 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 			return m, nil
 		}
 
-		// Handle main list view
-		switch msg.String() {
-		case "q":
+		// Cycle search matches while a query is active
+		if m.searchQuery != "" {
+			switch {
+			case m.keymap.MatchKey(msg, keymap.ActionNextMatch):
+				m.selectMessage(m.jumpToMatch(-1, m.selectedIndex, m.messages, m.userCache))
+				return m, nil
+			case m.keymap.MatchKey(msg, keymap.ActionPrevMatch):
+				m.selectMessage(m.jumpToMatch(1, m.selectedIndex, m.messages, m.userCache))
+				return m, nil
+			case m.keymap.MatchKey(msg, keymap.ActionCancel):
+				m.clearSearch()
+				return m, nil
+			}
+		}
+
+		// Handle main list view. A shown link or copy result is dismissed
+		// by the next key.
+		m.permalink = ""
+		m.yankNote = ""
+		m.yankText = ""
+		switch {
+		case m.keymap.MatchKey(msg, keymap.ActionQuit):
 			// Signal to exit live mode (handled by parent)
 			return m, nil
-		case "up", "k":
+		case m.keymap.MatchKey(msg, keymap.ActionSearch):
+			m.startSearch()
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionUp):
 			if m.selectedIndex > 0 {
 				m.selectedIndex--
 				m.ensureVisible()
@@ -781,13 +1028,41 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 				return m, m.loadOlderMessages()
 			}
 			return m, nil
-		case "down", "j":
+		case m.keymap.MatchKey(msg, keymap.ActionDown):
 			if m.selectedIndex < len(m.messages)-1 {
 				m.selectedIndex++
 				m.ensureVisible()
 			}
 			return m, nil
-		case "enter":
+		case m.keymap.MatchKey(msg, keymap.ActionTop):
+			// Jump to the oldest loaded message, loading more if available
+			m.selectedIndex = 0
+			m.scrollOffset = 0
+			if m.hasMoreMessages && !m.loadingOlder && len(m.messages) > 0 {
+				m.loadingOlder = true
+				return m, m.loadOlderMessages()
+			}
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionBottom):
+			// Jump to the newest message
+			if len(m.messages) > 0 {
+				m.selectedIndex = len(m.messages) - 1
+				m.ensureVisible()
+			}
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionHalfUp):
+			m.moveSelection(-m.halfPage())
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionHalfDown):
+			m.moveSelection(m.halfPage())
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionPageUp):
+			m.moveSelection(-m.getVisibleLines())
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionPageDown):
+			m.moveSelection(m.getVisibleLines())
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionOpenThread):
 			if len(m.messages) > 0 && m.selectedIndex < len(m.messages) {
 				selectedMsg := m.messages[m.selectedIndex]
 				// Use the message timestamp as thread_ts
@@ -799,13 +1074,14 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 				return m, m.loadThread(threadTS)
 			}
 			return m, nil
-		case "i":
+		case m.keymap.MatchKey(msg, keymap.ActionInputMode):
 			// New message input mode
 			m.inputMode = InputModeNewMessage
 			m.inputText.Placeholder = "Type a message..."
 			m.inputText.Focus()
+			m.restoreDraft()
 			return m, textarea.Blink
-		case "r":
+		case m.keymap.MatchKey(msg, keymap.ActionReply):
 			// Reply to selected message directly (create thread or reply in existing thread)
 			if len(m.messages) > 0 && m.selectedIndex < len(m.messages) {
 				selectedMsg := m.messages[m.selectedIndex]
@@ -817,15 +1093,16 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 				m.inputMode = InputModeReply
 				m.inputText.Placeholder = "Type your reply..."
 				m.inputText.Focus()
+				m.restoreDraft()
 				return m, textarea.Blink
 			}
 			return m, nil
-		case "R":
+		case m.keymap.MatchKey(msg, keymap.ActionRefresh):
 			// Reload messages
 			m.loading = true
 			m.loadingErr = nil
 			return m, m.loadMessages()
-		case "d":
+		case m.keymap.MatchKey(msg, keymap.ActionDelete):
 			// Delete selected message (show confirmation)
 			if len(m.messages) > 0 && m.selectedIndex < len(m.messages) {
 				selectedMsg := m.messages[m.selectedIndex]
@@ -835,7 +1112,7 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 				}
 			}
 			return m, nil
-		case "e":
+		case m.keymap.MatchKey(msg, keymap.ActionEdit):
 			// Edit selected message
 			if len(m.messages) > 0 && m.selectedIndex < len(m.messages) {
 				selectedMsg := m.messages[m.selectedIndex]
@@ -850,7 +1127,20 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 				}
 			}
 			return m, nil
-		case "n":
+		case m.keymap.MatchKey(msg, keymap.ActionYank):
+			// Copy the selected message's text
+			if len(m.messages) > 0 && m.selectedIndex < len(m.messages) {
+				return m, m.yankMessage(m.messages[m.selectedIndex].Text)
+			}
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionPermalink):
+			// Show the selected message's permalink to copy
+			if len(m.messages) > 0 && m.selectedIndex < len(m.messages) {
+				m.permalink = "Loading link..."
+				return m, m.loadPermalink(m.messages[m.selectedIndex].Timestamp)
+			}
+			return m, nil
+		case m.keymap.MatchKey(msg, keymap.ActionNotifications):
 			// Toggle notification panel
 			if len(m.notifications) > 0 {
 				m.showNotifyPanel = true
@@ -863,39 +1153,46 @@ func (m *LiveModel) Update(msg tea.Msg) (*LiveModel, tea.Cmd) {
 	return m, nil
 }
 
+// selectMessage selects the message at index, if it isn't -1
+func (m *LiveModel) selectMessage(index int) {
+	if index < 0 {
+		return
+	}
+	m.selectedIndex = index
+	m.ensureVisible()
+}
+
 // handlePeekModeKey handles key events in peek mode
 func (m *LiveModel) handlePeekModeKey(msg tea.KeyMsg) (*LiveModel, tea.Cmd) {
 	// Handle peek thread view
 	if m.peekThreadVisible {
-		switch msg.String() {
-		case "q", "esc":
+		if m.keymap.MatchKey(msg, keymap.ActionCloseThread) {
 			m.peekThreadVisible = false
 			m.peekThreadMessages = nil
 			m.peekThreadTS = ""
-			return m, nil
 		}
 		return m, nil
 	}
 
 	// Handle peek main view
-	switch msg.String() {
-	case "q", "esc":
+	switch {
+	case m.keymap.MatchKey(msg, keymap.ActionBack):
 		// Exit peek mode
 		m.exitPeekMode()
 		return m, nil
-	case "up", "k":
+	case m.keymap.MatchKey(msg, keymap.ActionUp):
 		if m.peekSelectedIndex > 0 {
 			m.peekSelectedIndex--
 			m.ensurePeekVisible()
 		}
 		return m, nil
-	case "down", "j":
+	case m.keymap.MatchKey(msg, keymap.ActionDown):
 		if m.peekSelectedIndex < len(m.peekMessages)-1 {
 			m.peekSelectedIndex++
 			m.ensurePeekVisible()
 		}
 		return m, nil
-	case "enter":
+	case m.keymap.MatchKey(msg, keymap.ActionOpenThread):
 		// View thread
 		if len(m.peekMessages) > 0 && m.peekSelectedIndex < len(m.peekMessages) {
 			selectedMsg := m.peekMessages[m.peekSelectedIndex]
@@ -913,28 +1210,28 @@ func (m *LiveModel) handlePeekModeKey(msg tea.KeyMsg) (*LiveModel, tea.Cmd) {
 
 // handleNotifyPanelKey handles key events in notification panel
 func (m *LiveModel) handleNotifyPanelKey(msg tea.KeyMsg) (*LiveModel, tea.Cmd) {
-	switch msg.String() {
-	case "q", "esc", "n":
+	switch {
+	case m.keymap.MatchKey(msg, keymap.ActionBack, keymap.ActionNotifications):
 		m.showNotifyPanel = false
 		return m, nil
-	case "up", "k":
+	case m.keymap.MatchKey(msg, keymap.ActionUp):
 		if m.notifyPanelIndex > 0 {
 			m.notifyPanelIndex--
 		}
 		return m, nil
-	case "down", "j":
+	case m.keymap.MatchKey(msg, keymap.ActionDown):
 		if m.notifyPanelIndex < len(m.notifications)-1 {
 			m.notifyPanelIndex++
 		}
 		return m, nil
-	case "enter":
+	case m.keymap.MatchKey(msg, keymap.ActionSelect):
 		// Enter peek mode for selected notification
 		if m.notifyPanelIndex < len(m.notifications) {
 			n := m.notifications[m.notifyPanelIndex]
 			return m, m.enterPeekMode(n.ChannelID, n.ChannelName, n.IsIM)
 		}
 		return m, nil
-	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+	case len(msg.String()) == 1 && msg.String()[0] >= '1' && msg.String()[0] <= '9':
 		// Quick select by number
 		idx := int(msg.String()[0] - '1')
 		if idx < len(m.notifications) {
@@ -976,6 +1273,30 @@ func (m *LiveModel) ensureVisible() {
 	}
 }
 
+// halfPage returns the number of messages to move for a half-page scroll
+func (m *LiveModel) halfPage() int {
+	half := m.getVisibleLines() / 2
+	if half < 1 {
+		return 1
+	}
+	return half
+}
+
+// moveSelection moves the selected message by delta, clamped to the list
+func (m *LiveModel) moveSelection(delta int) {
+	if len(m.messages) == 0 {
+		return
+	}
+	m.selectedIndex += delta
+	if m.selectedIndex < 0 {
+		m.selectedIndex = 0
+	}
+	if m.selectedIndex > len(m.messages)-1 {
+		m.selectedIndex = len(m.messages) - 1
+	}
+	m.ensureVisible()
+}
+
 func (m *LiveModel) ensurePeekVisible() {
 	visibleLines := m.getVisibleLines()
 
@@ -1083,6 +1404,9 @@ func (m *LiveModel) View() string {
 		sb.WriteString(m.renderMessageList())
 	}
 
+	// Typing indicator, just above the input
+	sb.WriteString(m.renderTyping())
+
 	// Input mode
 	if m.inputMode != InputModeNone {
 		sb.WriteString("\n")
@@ -1096,6 +1420,8 @@ func (m *LiveModel) View() string {
 		}
 		sb.WriteString(m.inputText.View())
 		sb.WriteString("\n")
+		sb.WriteString(m.renderCharCount())
+		sb.WriteString("\n")
 
 		// Show mention completion candidates
 		if m.mentionActive && len(m.mentionCandidates) > 0 {
@@ -1103,10 +1429,30 @@ func (m *LiveModel) View() string {
 		}
 	}
 
+	// Search bar
+	if m.searchMode || m.searchQuery != "" {
+		sb.WriteString("\n")
+		sb.WriteString(m.renderSearchBar())
+	}
+
 	// Delete confirmation
 	if m.deleteConfirm {
 		sb.WriteString("\n")
-		sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true).Render("Delete this message? (y/n)"))
+		sb.WriteString(errorStyle.Bold(true).Render("Delete this message? (y/n)"))
+		sb.WriteString("\n")
+	}
+
+	// Permalink of the selected message
+	if m.permalink != "" {
+		sb.WriteString("\n")
+		sb.WriteString(liveNormalStyle.Render("Link: " + m.permalink))
+		sb.WriteString("\n")
+	}
+
+	// Text of the selected message when it couldn't be copied
+	if m.yankText != "" {
+		sb.WriteString("\n")
+		sb.WriteString(liveNormalStyle.Render(m.yankText))
 		sb.WriteString("\n")
 	}
 
@@ -1118,6 +1464,32 @@ func (m *LiveModel) View() string {
 	return sb.String()
 }
 
+// renderTyping renders who is typing in the channel, dropping users whose
+// last typing event is older than typingTimeout
+func (m *LiveModel) renderTyping() string {
+	var names []string
+	for userID, at := range m.typing {
+		if time.Since(at) > typingTimeout {
+			delete(m.typing, userID)
+			continue
+		}
+		name := userID
+		if n, ok := m.userCache[userID]; ok {
+			name = n
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	verb := "is"
+	if len(names) > 1 {
+		verb = "are"
+	}
+	return liveHelpStyle.Italic(true).Render(fmt.Sprintf("%s %s typing…", strings.Join(names, ", "), verb)) + "\n"
+}
+
 func (m *LiveModel) renderMessageList() string {
 	var sb strings.Builder
 
@@ -1130,6 +1502,13 @@ func (m *LiveModel) renderMessageList() string {
 	visibleLines := m.getVisibleLines()
 	truncate := m.displayConfig.LiveTruncateMessages
 
+	// Highlight the search query first, then mentions of you
+	var terms []highlightTerm
+	if m.searchQuery != "" {
+		terms = append(terms, newHighlightTerm(m.searchQuery, liveSearchMatchStyle.Bold(true), false))
+	}
+	terms = append(terms, mentionTerms(m.selfName)...)
+
 	// Render messages starting from scrollOffset, counting lines
 	linesRendered := 0
 	endIdx := m.scrollOffset
@@ -1143,11 +1522,11 @@ func (m *LiveModel) renderMessageList() string {
 				break
 			}
 
+			style := liveNormalStyle
 			if i == m.selectedIndex {
-				sb.WriteString(liveSelectedStyle.Render(line))
-			} else {
-				sb.WriteString(liveNormalStyle.Render(line))
+				style = liveSelectedStyle
 			}
+			sb.WriteString(renderHighlighted(line, style, terms))
 			sb.WriteString("\n")
 			linesRendered++
 		}
@@ -1270,7 +1649,7 @@ func (m *LiveModel) formatMessageLines(msg slack.Message, index int, truncate bo
 
 	// Parse timestamp
 	ts := m.parseTimestamp(msg.Timestamp)
-	timeStr := ts.Format("01/02 15:04")
+	timeStr := m.displayConfig.FormatTime(ts, "01/02 15:04")
 
 	// Thread indicator
 	threadIndicator := ""
@@ -1278,13 +1657,39 @@ func (m *LiveModel) formatMessageLines(msg slack.Message, index int, truncate bo
 		threadIndicator = fmt.Sprintf(" [%d replies]", msg.ReplyCount)
 	}
 
-	// Resolve mentions in text and convert emoji
-	text := ConvertEmoji(ResolveMentions(msg.Text, m.userCache))
+	// Resolve mentions and markup in text and convert emoji
+	text := ConvertEmoji(RenderMrkdwn(msg.Text, m.userCache, nil))
 
 	// Header: [time] user:
-	header := fmt.Sprintf("[%s] %s: ", timeStr, userName)
+	header := fmt.Sprintf("%s[%s] %s: ", keywordMarker(m.keywords, msg.Text), timeStr, userName)
 	headerLen := utf8.RuneCountInString(header)
 
+	// Attachments and reactions go on their own lines under the message
+	indent := strings.Repeat(" ", headerLen)
+	var extraLines []string
+	for _, att := range msg.Attachments {
+		if att.Title != "" {
+			extraLines = append(extraLines, indent+"📎 "+ConvertEmoji(att.Title))
+		}
+		if att.Text != "" {
+			attText := ConvertEmoji(ResolveMentions(att.Text, m.userCache))
+			if truncate {
+				extraLines = append(extraLines, indent+"   "+truncatePreview(strings.ReplaceAll(attText, "\n", " "), m.width-30))
+				continue
+			}
+			attWidth := m.width - headerLen - 5
+			if attWidth < 20 {
+				attWidth = 20
+			}
+			for _, line := range m.wrapText(attText, attWidth) {
+				extraLines = append(extraLines, indent+"   "+line)
+			}
+		}
+	}
+	if len(msg.Reactions) > 0 && m.displayConfig.ReactionsVisible() {
+		extraLines = append(extraLines, indent+formatReactions(msg.Reactions))
+	}
+
 	if truncate {
 		maxLen := m.width - 30
 		if maxLen < 20 {
@@ -1295,7 +1700,7 @@ func (m *LiveModel) formatMessageLines(msg slack.Message, index int, truncate bo
 			text = string(textRunes[:maxLen-3]) + "..."
 		}
 		text = strings.ReplaceAll(text, "\n", " ")
-		return []string{header + text + threadIndicator}
+		return append([]string{header + text + threadIndicator}, extraLines...)
 	}
 
 	// Multi-line mode: wrap text
@@ -1317,7 +1722,6 @@ func (m *LiveModel) formatMessageLines(msg slack.Message, index int, truncate bo
 			}
 		} else {
 			// Continuation lines are indented
-			indent := strings.Repeat(" ", headerLen)
 			if i == len(wrappedLines)-1 {
 				result = append(result, indent+line+threadIndicator)
 			} else {
@@ -1326,7 +1730,7 @@ func (m *LiveModel) formatMessageLines(msg slack.Message, index int, truncate bo
 		}
 	}
 
-	return result
+	return append(result, extraLines...)
 }
 
 // getMessageLineCount returns the number of lines a message will take
@@ -1368,6 +1772,27 @@ func (m *LiveModel) renderMentionCandidates() string {
 	return sb.String()
 }
 
+// renderCharCount shows the length of the message being typed against the
+// character limit, turning yellow near the limit and red at it
+func (m *LiveModel) renderCharCount() string {
+	text := m.inputText.Value()
+	chars := utf8.RuneCountInString(text)
+	limit := m.inputText.CharLimit
+	count := fmt.Sprintf("%d words · %d/%d", len(strings.Fields(text)), chars, limit)
+
+	switch {
+	case chars >= limit:
+		return errorStyle.Render(count)
+	case chars >= limit*9/10:
+		return modeStyle.Render(count)
+	}
+	return liveHelpStyle.Render(count)
+}
+
+func (m *LiveModel) renderSearchBar() string {
+	return m.messageSearch.renderSearchBar(liveSearchBarStyle)
+}
+
 func (m *LiveModel) renderNotificationBar() string {
 	if len(m.notifications) == 0 {
 		return ""
@@ -1385,11 +1810,7 @@ func (m *LiveModel) renderNotificationBar() string {
 	}
 
 	// Truncate message preview (use runes for proper multi-byte support)
-	preview := n.LastMessage
-	previewRunes := []rune(preview)
-	if len(previewRunes) > 25 {
-		preview = string(previewRunes[:22]) + "..."
-	}
+	preview := truncatePreview(n.LastMessage, m.displayConfig.GetPreviewLength(25))
 
 	// Format: 📨 #channel (count) | @user: message... [n: 確認]
 	totalCount := 0
@@ -1410,9 +1831,16 @@ func (m *LiveModel) renderNotificationBar() string {
 func (m *LiveModel) renderNotificationPanel() string {
 	var sb strings.Builder
 
+	// Widen the panel when a longer preview length is configured
+	previewLen := m.displayConfig.GetPreviewLength(20)
+	panelWidth := 55
+	if previewLen > 20 {
+		panelWidth += previewLen - 20
+	}
+
 	sb.WriteString("\n")
 	sb.WriteString("┌─ Notifications ")
-	sb.WriteString(strings.Repeat("─", 40))
+	sb.WriteString(strings.Repeat("─", panelWidth-15))
 	sb.WriteString("┐\n")
 
 	for i, n := range m.notifications {
@@ -1422,31 +1850,27 @@ func (m *LiveModel) renderNotificationPanel() string {
 		}
 
 		// Truncate message preview (use runes for proper multi-byte support)
-		preview := n.LastMessage
-		previewRunes := []rune(preview)
-		if len(previewRunes) > 20 {
-			preview = string(previewRunes[:17]) + "..."
-		}
+		preview := truncatePreview(n.LastMessage, previewLen)
 
 		line := fmt.Sprintf(" %d. %s%-12s (%d) @%s: %s",
 			i+1, prefix, truncateString(n.ChannelName, 12), n.Count, truncateString(n.LastUser, 10), preview)
 
 		if i == m.notifyPanelIndex {
-			sb.WriteString("│" + liveSelectedStyle.Render(padRight(line, 55)) + "│\n")
+			sb.WriteString("│" + liveSelectedStyle.Render(padRight(line, panelWidth)) + "│\n")
 		} else {
-			sb.WriteString("│" + liveNormalStyle.Render(padRight(line, 55)) + "│\n")
+			sb.WriteString("│" + liveNormalStyle.Render(padRight(line, panelWidth)) + "│\n")
 		}
 	}
 
 	// Fill empty space if fewer than 5 notifications
 	for i := len(m.notifications); i < 5; i++ {
-		sb.WriteString("│" + strings.Repeat(" ", 55) + "│\n")
+		sb.WriteString("│" + strings.Repeat(" ", panelWidth) + "│\n")
 	}
 
-	sb.WriteString("│" + strings.Repeat(" ", 55) + "│\n")
-	sb.WriteString("│ " + liveHelpStyle.Render("[1-9]: peek  Enter: select  j/k: move  q/Esc: back") + " │\n")
+	sb.WriteString("│" + strings.Repeat(" ", panelWidth) + "│\n")
+	sb.WriteString("│ " + liveHelpStyle.Render(padRight("[1-9]: peek  Enter: select  j/k: move  q/Esc: back", panelWidth-2)) + " │\n")
 	sb.WriteString("└")
-	sb.WriteString(strings.Repeat("─", 55))
+	sb.WriteString(strings.Repeat("─", panelWidth))
 	sb.WriteString("┘")
 
 	return sb.String()
@@ -1577,6 +2001,15 @@ func truncateString(s string, maxLen int) string {
 	return s
 }
 
+// truncatePreview shortens a message preview to maxLen runes, ending with "..."
+func truncatePreview(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) > maxLen && maxLen > 3 {
+		return string(runes[:maxLen-3]) + "..."
+	}
+	return s
+}
+
 func padRight(s string, length int) string {
 	runes := []rune(s)
 	if len(runes) >= length {
@@ -1601,15 +2034,22 @@ func (m *LiveModel) renderHelp() string {
 		}
 	} else if m.showNotifyPanel {
 		help = "[1-9]: peek | Enter: select | j/k: move | q/Esc: close"
+	} else if m.searchMode {
+		help = "Type to search | Enter: confirm | Esc: cancel"
 	} else if m.threadVisible {
 		help = "r: reply | q/Esc: back | j/k: scroll"
+	} else if m.searchQuery != "" {
+		help = "n: older match | N: newer match | Esc: clear search | Enter: thread | j/k: nav | q: exit"
 	} else {
-		help = "i: message | Enter: thread | r: reply | e: edit | d: delete | R: reload | j/k: nav"
+		help = "i: message | Enter: thread | r: reply | e: edit | d: delete | y: copy | Y: link | /: search | R: reload | j/k/g/G: nav"
 		if len(m.notifications) > 0 {
 			help += " | n: notifications"
 		}
 		help += " | q: exit"
 	}
+	if m.yankNote != "" {
+		help = m.yankNote + " | " + help
+	}
 	return "\n" + liveHelpStyle.Render(help)
 }
 
@@ -1675,6 +2115,34 @@ func (m *LiveModel) RemovePeekDeletedMessage(channelID, deletedTimestamp string)
 	}
 }
 
+// UpdateEditedMessage replaces the text of a message edited via realtime events
+func (m *LiveModel) UpdateEditedMessage(channelID, timestamp, text string) {
+	// Only update if it's for this channel
+	if channelID != m.channelID {
+		return
+	}
+	if updateMessageText(m.messages, timestamp, text) {
+		return
+	}
+	if m.threadVisible {
+		updateMessageText(m.threadMessages, timestamp, text)
+	}
+}
+
+// UpdatePeekEditedMessage replaces the text of a message in peek view edited via realtime events
+func (m *LiveModel) UpdatePeekEditedMessage(channelID, timestamp, text string) {
+	// Only update if in peek mode and message is for the peek channel
+	if !m.peekMode || channelID != m.peekChannelID {
+		return
+	}
+	if updateMessageText(m.peekMessages, timestamp, text) {
+		return
+	}
+	if m.peekThreadVisible {
+		updateMessageText(m.peekThreadMessages, timestamp, text)
+	}
+}
+
 // AddIncomingMessage adds a new message from realtime events
 func (m *LiveModel) AddIncomingMessage(channelID, userID, userName, text, timestamp, threadTS string) {
 	// Only add if it's for this channel
@@ -1691,6 +2159,9 @@ func (m *LiveModel) AddIncomingMessage(channelID, userID, userName, text, timest
 		ThreadTS:  threadTS,
 	}
 
+	// The user has stopped typing
+	delete(m.typing, userID)
+
 	// If this is a thread reply to the currently viewed thread
 	if m.threadVisible && threadTS != "" && threadTS == m.threadTS {
 		m.threadMessages = append(m.threadMessages, newMsg)
@@ -1708,6 +2179,90 @@ func (m *LiveModel) AddIncomingMessage(channelID, userID, userName, text, timest
 	}
 }
 
+// AddTypingUser shows userID as typing if the event is for this channel.
+// The returned command redraws the view once the indicator has expired.
+func (m *LiveModel) AddTypingUser(evt slack.TypingEvent) tea.Cmd {
+	if evt.ChannelID != m.channelID || m.peekMode {
+		return nil
+	}
+	if m.typing == nil {
+		m.typing = make(map[string]time.Time)
+	}
+	m.typing[evt.UserID] = time.Now()
+	return tea.Tick(typingTimeout, func(time.Time) tea.Msg {
+		return LiveTypingExpiredMsg{}
+	})
+}
+
+// ApplyReaction updates reaction counts for a message in this channel
+func (m *LiveModel) ApplyReaction(evt slack.ReactionEvent) {
+	if evt.ChannelID != m.channelID {
+		return
+	}
+	if applyReaction(m.messages, evt) {
+		return
+	}
+	if m.threadVisible {
+		applyReaction(m.threadMessages, evt)
+	}
+}
+
+// updateMessageText sets the text of the message matching timestamp.
+// Returns false if no message matched.
+func updateMessageText(messages []slack.Message, timestamp, text string) bool {
+	for i := range messages {
+		if messages[i].Timestamp == timestamp {
+			messages[i].Text = text
+			return true
+		}
+	}
+	return false
+}
+
+// applyReaction adds or removes one reaction on the message matching
+// evt.Timestamp. Returns false if no message matched.
+func applyReaction(messages []slack.Message, evt slack.ReactionEvent) bool {
+	for i := range messages {
+		if messages[i].Timestamp != evt.Timestamp {
+			continue
+		}
+		msg := &messages[i]
+
+		for j := range msg.Reactions {
+			r := &msg.Reactions[j]
+			if r.Name != evt.Reaction {
+				continue
+			}
+			if evt.Added {
+				r.Count++
+				r.Users = append(r.Users, evt.UserID)
+				return true
+			}
+			r.Count--
+			for k, u := range r.Users {
+				if u == evt.UserID {
+					r.Users = append(r.Users[:k], r.Users[k+1:]...)
+					break
+				}
+			}
+			if r.Count <= 0 {
+				msg.Reactions = append(msg.Reactions[:j], msg.Reactions[j+1:]...)
+			}
+			return true
+		}
+
+		if evt.Added {
+			msg.Reactions = append(msg.Reactions, slack.Reaction{
+				Name:  evt.Reaction,
+				Count: 1,
+				Users: []string{evt.UserID},
+			})
+		}
+		return true
+	}
+	return false
+}
+
 // AddPeekIncomingMessage adds a message to the peek view if in peek mode
 func (m *LiveModel) AddPeekIncomingMessage(channelID, userID, userName, text, timestamp, threadTS string) {
 	// Only add if in peek mode and message is for the peek channel
@@ -1750,10 +2305,10 @@ func (m *LiveModel) GetChannelID() string {
 func (m *LiveModel) ShouldExit(msg tea.KeyMsg) bool {
 	// Only exit on 'q' when not in input mode, not in thread view, not confirming delete,
 	// not in peek mode, and not showing notification panel
-	if m.inputMode != InputModeNone || m.threadVisible || m.deleteConfirm || m.peekMode || m.showNotifyPanel {
+	if m.inputMode != InputModeNone || m.threadVisible || m.deleteConfirm || m.peekMode || m.showNotifyPanel || m.searchMode {
 		return false
 	}
-	return msg.String() == "q"
+	return m.keymap.MatchKey(msg, keymap.ActionQuit)
 }
 
 // IsInInputMode returns true if live model is in input mode
@@ -2,27 +2,26 @@ package shell
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/polidog/slack-shell/internal/cache"
 	"github.com/polidog/slack-shell/internal/config"
+	"github.com/polidog/slack-shell/internal/keymap"
 	"github.com/polidog/slack-shell/internal/notification"
 	"github.com/polidog/slack-shell/internal/slack"
+	"github.com/polidog/slack-shell/internal/ui/styles"
 )
 
 var (
-	promptStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
-	outputStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
-	errorStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
-	modeStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
-	newMsgStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
-	notificationStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("15")).
-				Background(lipgloss.Color("4")).
-				Padding(0, 1)
+	newMsgStyle       = lipgloss.NewStyle().Foreground(styles.Color("2"))
+	connectedStyle    = lipgloss.NewStyle().Foreground(styles.Color("2"))
+	disconnectedStyle = lipgloss.NewStyle().Foreground(styles.Color("9"))
+	reconnectingStyle = lipgloss.NewStyle().Foreground(styles.Color("3"))
 )
 
 // Model is the Bubble Tea model for the shell UI
@@ -32,6 +31,8 @@ type Model struct {
 	notificationManager *notification.Manager
 	executor            *Executor
 	input               textinput.Model
+	promptStyle         lipgloss.Style
+	promptHeader        string // Rendered prompt lines above the input
 	history             []string
 	historyIndex        int
 	commandHistory      []string
@@ -46,6 +47,12 @@ type Model struct {
 	// Live mode
 	liveMode  bool
 	liveModel *LiveModel
+	// Unsent live mode messages, kept across live sessions
+	drafts map[string]string
+
+	// Split live mode (two channels side by side)
+	splitMode  bool
+	splitModel *SplitLiveModel
 
 	// Tab completion
 	completionCandidates []string
@@ -53,35 +60,116 @@ type Model struct {
 	completionActive     bool
 	originalInput        string
 
+	// Pending y/n confirmation (e.g. rm)
+	pendingConfirm *ConfirmRequest
+
+	// Multi-line compose mode (send -m or a multi-line paste)
+	composeMode bool
+	compose     textarea.Model
+	composeCmd  Command
+
 	// Startup config
 	startupConfig *config.StartupConfig
+
+	// Key bindings for browse/live modes
+	keymap *keymap.Keymap
+
+	// Realtime connection status (only shown when an app token is configured)
+	hasAppToken  bool
+	connected    bool
+	reconnecting bool
 }
 
 // NewModel creates a new shell model
 func NewModel(client *slack.Client, notifyMgr *notification.Manager, promptConfig *config.PromptConfig, displayConfig *config.DisplayConfig, startupConfig *config.StartupConfig, hasAppToken bool) *Model {
 	executor := NewExecutorWithCache(client, promptConfig, displayConfig, hasAppToken, nil, nil)
+	executor.SetNotificationManager(notifyMgr)
+
+	applyTheme(styles.CurrentTheme())
+
+	prompt := newPromptStyle(executor.promptConfig)
 
 	ti := textinput.New()
-	ti.Prompt = promptStyle.Render(executor.GetPrompt())
 	ti.Focus()
 	ti.CharLimit = 1000
 	ti.Width = 80
 
-	return &Model{
+	m := &Model{
 		client:              client,
 		notificationManager: notifyMgr,
 		executor:            executor,
 		input:               ti,
+		promptStyle:         prompt,
 		history:             []string{},
 		historyIndex:        -1,
 		commandHistory:      []string{},
+		drafts:              make(map[string]string),
 		startupConfig:       startupConfig,
+		hasAppToken:         hasAppToken,
+	}
+	m.refreshPrompt()
+	return m
+}
+
+// viewedChannels returns the IDs of the channels whose messages are on
+// screen: the live (and peek) channel, the browsed channel, or the current
+// channel at the prompt
+func (m *Model) viewedChannels() map[string]bool {
+	viewed := make(map[string]bool)
+	switch {
+	case m.liveMode && m.liveModel != nil:
+		viewed[m.liveModel.GetChannelID()] = true
+		if m.liveModel.IsPeekMode() {
+			viewed[m.liveModel.GetPeekChannelID()] = true
+		}
+	case m.splitMode && m.splitModel != nil:
+		for _, id := range m.splitModel.ChannelIDs() {
+			viewed[id] = true
+		}
+	case m.browseMode && m.browseModel != nil:
+		viewed[m.browseModel.GetChannelID()] = true
+	default:
+		if ch := m.executor.GetCurrentChannel(); ch != nil {
+			viewed[ch.ID] = true
+		}
+	}
+	return viewed
+}
+
+// newPromptStyle builds the prompt style from config, falling back to cyan
+func newPromptStyle(promptConfig *config.PromptConfig) lipgloss.Style {
+	color := "6"
+	if promptConfig.Color != "" {
+		color = promptConfig.Color
 	}
+	return lipgloss.NewStyle().Foreground(styles.Color(color)).Bold(promptConfig.Bold)
+}
+
+// refreshPrompt re-renders the prompt. With {newline} in the format, the
+// lines before the last are drawn above the input.
+func (m *Model) refreshPrompt() {
+	header, line := splitPrompt(m.executor.GetPrompt())
+	m.promptHeader = ""
+	if header != "" {
+		m.promptHeader = renderPrompt(header, m.promptStyle)
+	}
+	m.input.Prompt = renderPromptLine(line, m.promptStyle)
 }
 
 // SetRealtimeClient sets the realtime client for receiving messages
 func (m *Model) SetRealtimeClient(rc *slack.RealtimeClient) {
 	m.realtimeClient = rc
+	m.executor.SetRealtimeClient(rc)
+}
+
+// SetKeymap sets the key bindings used in browse and live modes
+func (m *Model) SetKeymap(km *keymap.Keymap) {
+	m.keymap = km
+}
+
+// SetCompletionConfig sets the tab completion settings for the executor
+func (m *Model) SetCompletionConfig(completionConfig *config.CompletionConfig) {
+	m.executor.SetCompletionConfig(completionConfig)
 }
 
 // SetUserCache sets the user cache for the executor
@@ -125,26 +213,86 @@ func (m *Model) Init() tea.Cmd {
 	}
 	m.history = append(m.history, "Type 'help' for available commands.\n")
 
-	// Execute init commands
-	if m.startupConfig != nil && len(m.startupConfig.InitCommands) > 0 {
-		for _, cmdStr := range m.startupConfig.InitCommands {
-			m.history = append(m.history, promptStyle.Render(m.executor.GetPrompt())+cmdStr)
+	// Execute init commands, then the rc file
+	var initCommands []string
+	if m.startupConfig != nil {
+		initCommands = append(initCommands, m.startupConfig.InitCommands...)
+		rcCommands, err := loadRCFile(m.startupConfig.RCFile)
+		if err != nil {
+			m.history = append(m.history, initErrorStyle.Render(fmt.Sprintf("✗ failed to read rc file: %v", err)))
+		}
+		initCommands = append(initCommands, rcCommands...)
+	}
+	if len(initCommands) > 0 {
+		replacer := strings.NewReplacer(
+			"{workspace}", workspaceName,
+			"{user}", m.client.GetUserName(),
+		)
+		for i, cmdStr := range initCommands {
+			cmdStr = replacer.Replace(cmdStr)
+			if !m.startupConfig.Quiet {
+				m.history = append(m.history, renderPrompt(m.executor.GetPrompt(), m.promptStyle)+cmdStr)
+			}
 			pipeline := ParsePipeline(cmdStr)
 			result := m.executor.ExecutePipeline(pipeline)
 			if result.Output != "" {
 				m.history = append(m.history, result.Output)
 			}
+
+			var err error
 			if result.Error != nil {
-				m.history = append(m.history, errorStyle.Render(fmt.Sprintf("Error: %v", result.Error)))
+				err = result.Error
+			} else if result.Confirm != nil {
+				err = fmt.Errorf("confirmation required in init commands (use --yes)")
+			}
+			if err == nil {
+				continue
+			}
+			m.history = append(m.history, initErrorStyle.Render(fmt.Sprintf("✗ init command %q failed: %v", cmdStr, err)))
+
+			if m.startupConfig.StopOnError {
+				if skipped := len(initCommands) - i - 1; skipped > 0 {
+					m.history = append(m.history, initErrorStyle.Render(fmt.Sprintf("✗ skipped %d remaining init command(s) (stop_on_error)", skipped)))
+				}
+				break
 			}
 		}
 		// Update prompt after init commands
-		m.input.Prompt = promptStyle.Render(m.executor.GetPrompt())
+		m.refreshPrompt()
 	}
 
 	return textinput.Blink
 }
 
+// loadRCFile reads startup commands from path, one per line. Blank lines
+// and lines starting with # are ignored. A missing file yields no commands.
+func loadRCFile(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	path, err := expandPath(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var commands []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		commands = append(commands, line)
+	}
+	return commands, nil
+}
+
 // Update handles messages
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -165,6 +313,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		// Handle split live mode key events
+		if m.splitMode {
+			if m.splitModel.ShouldExit(msg) {
+				m.splitMode = false
+				m.splitModel = nil
+				m.history = append(m.history, modeStyle.Render("Exited live mode."))
+				m.input.Focus()
+				return m, nil
+			}
+			m.splitModel, cmd = m.splitModel.Update(msg)
+			return m, cmd
+		}
+
 		// Handle browse mode key events
 		if m.browseMode {
 			// Check for exit condition first
@@ -179,6 +340,23 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		if m.composeMode {
+			return m.updateCompose(msg)
+		}
+
+		// A multi-line paste into a send command opens compose mode so the
+		// newlines are kept
+		if msg.Paste && strings.ContainsRune(string(msg.Runes), '\n') {
+			cmd := ParseCommand(m.input.Value())
+			if cmd.Type == CmdSend {
+				text := strings.Join(cmd.Args, " ")
+				if text != "" {
+					text += " "
+				}
+				return m.startCompose(cmd, text+string(msg.Runes))
+			}
+		}
+
 		// Normal mode key handling
 		switch msg.Type {
 		case tea.KeyCtrlC:
@@ -188,6 +366,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.history = nil
 			return m, tea.Batch(tea.ClearScreen, tea.WindowSize())
 
+		case tea.KeyCtrlN:
+			if m.notificationManager != nil {
+				m.notificationManager.DismissAllVisualNotifications()
+			}
+			return m, nil
+
 		case tea.KeyEnter:
 			m.resetCompletion()
 			return m.executeCommand()
@@ -214,12 +398,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.input.Width = msg.Width - 10
+		if m.composeMode {
+			m.compose.SetWidth(msg.Width - 2)
+		}
 		m.ready = true
 		// Update live model dimensions if active
 		if m.liveMode && m.liveModel != nil {
 			m.liveModel, cmd = m.liveModel.Update(msg)
 			return m, cmd
 		}
+		if m.splitMode && m.splitModel != nil {
+			m.splitModel, cmd = m.splitModel.Update(msg)
+			return m, cmd
+		}
 		// Update browse model dimensions if active
 		if m.browseMode && m.browseModel != nil {
 			m.browseModel, cmd = m.browseModel.Update(msg)
@@ -227,12 +418,23 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	// Handle live mode messages
-	case LiveMessagesLoadedMsg, LiveThreadLoadedMsg, LiveMessageSentMsg, LiveReplySentMsg, LiveOlderMessagesLoadedMsg, LiveMembersLoadedMsg, PeekMessagesLoadedMsg, PeekThreadLoadedMsg:
+	case LiveMessagesLoadedMsg, LiveThreadLoadedMsg, LiveMessageSentMsg, LiveReplySentMsg, LiveOlderMessagesLoadedMsg, LiveMembersLoadedMsg, LivePermalinkMsg, LiveYankedMsg, LiveTypingExpiredMsg, PeekMessagesLoadedMsg, PeekThreadLoadedMsg:
 		if m.liveMode && m.liveModel != nil {
 			m.liveModel, cmd = m.liveModel.Update(msg)
 			return m, cmd
 		}
 
+	// Handle split live mode messages
+	case splitPaneMsg:
+		if entered, ok := msg.Msg.(PeekModeEnteredMsg); ok && m.notificationManager != nil {
+			m.notificationManager.ClearUnread(entered.ChannelID)
+		}
+		if m.splitMode && m.splitModel != nil {
+			m.splitModel, cmd = m.splitModel.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
 	// Handle peek mode entered - clear unread from notification manager
 	case PeekModeEnteredMsg:
 		if m.notificationManager != nil {
@@ -241,7 +443,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	// Handle browse mode messages
-	case MessagesLoadedMsg, ThreadLoadedMsg, ReplySentMsg:
+	case MessagesLoadedMsg, ThreadLoadedMsg, ReplySentMsg, PermalinkMsg, BrowseRefreshTickMsg:
 		if m.browseMode && m.browseModel != nil {
 			m.browseModel, cmd = m.browseModel.Update(msg)
 			return m, cmd
@@ -280,11 +482,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				isIM := m.executor.IsIMChannel(slackMsg.ChannelID)
 
 				// Truncate message for preview (use runes for proper multi-byte support)
-				preview := slackMsg.Text
-				previewRunes := []rune(preview)
-				if len(previewRunes) > 30 {
-					preview = string(previewRunes[:27]) + "..."
-				}
+				preview := truncatePreview(slackMsg.Text, m.executor.displayConfig.GetPreviewLength(30))
 
 				m.liveModel.AddNotification(NotificationItem{
 					ChannelID:   slackMsg.ChannelID,
@@ -296,6 +494,27 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Handle split live mode - route the message to the matching pane
+		if m.splitMode && m.splitModel != nil {
+			shown := m.splitModel.AddIncomingMessage(
+				slackMsg.ChannelID,
+				slackMsg.UserID,
+				userName,
+				slackMsg.Text,
+				slackMsg.Timestamp,
+				slackMsg.ThreadTS,
+			)
+			if !shown && slackMsg.UserID != m.executor.GetCurrentUserID() {
+				m.splitModel.AddNotification(NotificationItem{
+					ChannelID:   slackMsg.ChannelID,
+					ChannelName: m.executor.GetChannelName(slackMsg.ChannelID),
+					IsIM:        m.executor.IsIMChannel(slackMsg.ChannelID),
+					LastMessage: truncatePreview(slackMsg.Text, m.executor.displayConfig.GetPreviewLength(30)),
+					LastUser:    userName,
+				})
+			}
+		}
+
 		// Handle browse mode - add message to browse view
 		if m.browseMode && m.browseModel != nil {
 			m.browseModel.AddIncomingMessage(
@@ -310,18 +529,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Trigger notifications for messages from other channels (skip self messages)
 		if m.notificationManager != nil && slackMsg.UserID != m.executor.GetCurrentUserID() {
-			currentChannelID := ""
-			currentChannel := m.executor.GetCurrentChannel()
-			if currentChannel != nil {
-				currentChannelID = currentChannel.ID
-			}
-
 			// Get channel and user info for notification
 			channelName := m.executor.GetChannelName(slackMsg.ChannelID)
 			userName := m.executor.GetUserName(slackMsg.UserID)
 			isMention := m.executor.IsMentionedInMessage(slackMsg.Text)
 
 			notifyMsg := notification.Message{
+				TeamID:      m.client.GetTeamID(),
 				ChannelID:   slackMsg.ChannelID,
 				ChannelName: channelName,
 				UserName:    userName,
@@ -330,7 +544,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				IsIM:        m.executor.IsIMChannel(slackMsg.ChannelID),
 			}
 
-			m.notificationManager.HandleMessage(notifyMsg, currentChannelID, m.browseMode || m.liveMode)
+			m.notificationManager.HandleMessage(notifyMsg, m.viewedChannels())
 		}
 		return m, nil
 
@@ -345,88 +559,308 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.liveModel.RemovePeekDeletedMessage(deletedMsg.ChannelID, deletedMsg.DeletedTimestamp)
 			}
 		}
+		if m.splitMode && m.splitModel != nil {
+			m.splitModel.RemoveDeletedMessage(deletedMsg.ChannelID, deletedMsg.DeletedTimestamp)
+		}
 
 		// Handle browse mode - remove message from browse view
 		if m.browseMode && m.browseModel != nil {
 			m.browseModel.RemoveDeletedMessage(deletedMsg.ChannelID, deletedMsg.DeletedTimestamp)
 		}
 		return m, nil
+
+	case EditedMessageMsg:
+		editedMsg := slack.EditedMessage(msg)
+
+		// Handle live mode - update the message text in live view
+		if m.liveMode && m.liveModel != nil {
+			if editedMsg.ChannelID == m.liveModel.GetChannelID() {
+				m.liveModel.UpdateEditedMessage(editedMsg.ChannelID, editedMsg.Timestamp, editedMsg.Text)
+			} else if m.liveModel.IsPeekMode() && editedMsg.ChannelID == m.liveModel.GetPeekChannelID() {
+				m.liveModel.UpdatePeekEditedMessage(editedMsg.ChannelID, editedMsg.Timestamp, editedMsg.Text)
+			}
+		}
+		if m.splitMode && m.splitModel != nil {
+			m.splitModel.UpdateEditedMessage(editedMsg.ChannelID, editedMsg.Timestamp, editedMsg.Text)
+		}
+
+		// Handle browse mode - update the message text in browse view
+		if m.browseMode && m.browseModel != nil {
+			m.browseModel.UpdateEditedMessage(editedMsg.ChannelID, editedMsg.Timestamp, editedMsg.Text)
+		}
+		return m, nil
+
+	case ReactionEventMsg:
+		if m.liveMode && m.liveModel != nil {
+			m.liveModel.ApplyReaction(slack.ReactionEvent(msg))
+		}
+		if m.splitMode && m.splitModel != nil {
+			m.splitModel.ApplyReaction(slack.ReactionEvent(msg))
+		}
+		if m.browseMode && m.browseModel != nil {
+			m.browseModel.ApplyReaction(slack.ReactionEvent(msg))
+		}
+		return m, nil
+
+	case TypingEventMsg:
+		if m.liveMode && m.liveModel != nil {
+			return m, m.liveModel.AddTypingUser(slack.TypingEvent(msg))
+		}
+		if m.splitMode && m.splitModel != nil {
+			return m, m.splitModel.AddTypingUser(slack.TypingEvent(msg))
+		}
+		return m, nil
+
+	case ConnectionStatusMsg:
+		m.connected = msg.Connected
+		m.reconnecting = msg.Reconnecting
+		if msg.Err != nil {
+			m.history = append(m.history, errorStyle.Render(fmt.Sprintf("Socket Mode stopped: %v", msg.Err)))
+		}
+		return m, nil
 	}
 
-	if !m.browseMode && !m.liveMode {
+	if m.composeMode {
+		m.compose, cmd = m.compose.Update(msg)
+	} else if !m.browseMode && !m.liveMode && !m.splitMode {
 		m.input, cmd = m.input.Update(msg)
 	}
 	return m, cmd
 }
 
+// startCompose replaces the input line with a multi-line textarea. The
+// message is sent with cmd's flags (e.g. --reply-to) when submitted.
+func (m *Model) startCompose(cmd Command, text string) (tea.Model, tea.Cmd) {
+	if m.executor.GetCurrentChannel() == nil {
+		m.history = append(m.history, errorStyle.Render("Not in a channel. Use 'cd #channel' first."))
+		m.input.SetValue("")
+		return m, nil
+	}
+
+	ta := textarea.New()
+	ta.Placeholder = "Type a message..."
+	ta.CharLimit = 4000
+	ta.ShowLineNumbers = false
+	ta.SetWidth(m.width - 2)
+	ta.SetHeight(5)
+	ta.SetValue(text)
+	ta.Focus()
+
+	m.composeCmd = Command{Type: CmdSend, Flags: cmd.Flags}
+	delete(m.composeCmd.Flags, "m")
+	m.compose = ta
+	m.composeMode = true
+	m.input.SetValue("")
+	m.input.Blur()
+
+	return m, textarea.Blink
+}
+
+// updateCompose handles keys in compose mode: Ctrl+D sends, Esc cancels and
+// everything else (including Enter) edits the message
+func (m *Model) updateCompose(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+
+	case tea.KeyEsc:
+		m.endCompose()
+		m.history = append(m.history, outputStyle.Render("Cancelled."))
+		return m, nil
+
+	case tea.KeyCtrlD:
+		text := strings.TrimSpace(m.compose.Value())
+		cmd := m.composeCmd
+		m.endCompose()
+		if text == "" {
+			m.history = append(m.history, outputStyle.Render("Cancelled."))
+			return m, nil
+		}
+
+		m.history = append(m.history, plainPrompt(m.executor.GetPrompt())+"send -m")
+		for _, line := range strings.Split(text, "\n") {
+			m.history = append(m.history, "  "+line)
+		}
+		result := m.executor.postMessage(cmd, text)
+		if result.Error != nil {
+			m.history = append(m.history, errorStyle.Render(FormatError(result.Error)))
+		} else if result.Output != "" {
+			m.history = append(m.history, outputStyle.Render(result.Output))
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.compose, cmd = m.compose.Update(msg)
+	return m, cmd
+}
+
+// endCompose leaves compose mode and returns focus to the input line
+func (m *Model) endCompose() {
+	m.composeMode = false
+	m.compose.Blur()
+	m.input.Focus()
+	m.refreshPrompt()
+}
+
 func (m *Model) executeCommand() (tea.Model, tea.Cmd) {
 	input := strings.TrimSpace(m.input.Value())
 
+	// Answer a pending confirmation
+	if m.pendingConfirm != nil {
+		return m.answerConfirm(input)
+	}
+
 	// Add to history display
-	m.history = append(m.history, m.executor.GetPrompt()+input)
+	m.history = append(m.history, plainPrompt(m.executor.GetPrompt())+input)
 
 	if input != "" {
 		// Add to command history
 		m.commandHistory = append(m.commandHistory, input)
 		m.historyIndex = len(m.commandHistory)
 
-		var result ExecuteResult
-		var parsedCmd Command
+		// Run each command of a && / ; chain. A command after && is skipped
+		// when the previous one failed.
+		failed := false
+		for _, chained := range SplitCommands(input) {
+			if chained.Conditional && failed {
+				continue
+			}
+			failed = false
 
-		// Check if this is a pipeline
-		if IsPipeline(input) {
-			pipeline := ParsePipeline(input)
-			result = m.executor.ExecutePipeline(pipeline)
-		} else {
-			// Parse and execute single command
-			parsedCmd = ParseCommand(input)
+			segment := strings.TrimSpace(chained.Input)
+			if segment == "" {
+				continue
+			}
+
+			var result ExecuteResult
+			var parsedCmd Command
+
+			// Check if this is a pipeline
+			if IsPipeline(segment) {
+				pipeline := ParsePipeline(segment)
+				result = m.executor.ExecutePipeline(pipeline)
+			} else {
+				// Parse and execute single command
+				parsedCmd = ParseCommand(segment)
+
+				// Handle browse command specially
+				if parsedCmd.Type == CmdBrowse {
+					return m.startBrowseMode(parsedCmd)
+				}
 
-			// Handle browse command specially
-			if parsedCmd.Type == CmdBrowse {
-				return m.startBrowseMode(parsedCmd)
+				// Handle live command specially
+				if parsedCmd.Type == CmdLive {
+					return m.startLiveMode(parsedCmd)
+				}
+
+				// send -m opens the multi-line compose mode
+				if parsedCmd.Type == CmdSend && parsedCmd.GetFlagBool("m") {
+					text := strings.Join(parsedCmd.Args, " ")
+					if first := parsedCmd.Flags["m"]; first != "true" {
+						// The parser took the first word as the flag's value
+						text = strings.TrimSpace(first + " " + text)
+					}
+					return m.startCompose(parsedCmd, text)
+				}
+
+				result = m.executor.Execute(parsedCmd)
 			}
 
-			// Handle live command specially
-			if parsedCmd.Type == CmdLive {
-				return m.startLiveMode(parsedCmd)
+			if result.Exit {
+				return m, tea.Quit
 			}
 
-			result = m.executor.Execute(parsedCmd)
+			if result.Error != nil {
+				m.history = append(m.history, errorStyle.Render(FormatError(result.Error)))
+				failed = true
+			} else if result.Confirm != nil {
+				// The rest of the chain is dropped while waiting for the answer
+				m.pendingConfirm = result.Confirm
+				m.input.SetValue("")
+				m.input.Prompt = m.promptStyle.Render(result.Confirm.Prompt)
+				m.promptHeader = ""
+				return m, nil
+			} else if result.SwitchWorkspace != nil {
+				// Handle workspace switch
+				m.client = result.SwitchWorkspace.Client
+				m.executor.SwitchClient(result.SwitchWorkspace.Client)
+				m.history = append(m.history, outputStyle.Render(
+					"Switched to workspace: "+result.SwitchWorkspace.TeamName))
+				for _, w := range result.SwitchWorkspace.Config.Warnings {
+					m.history = append(m.history, modeStyle.Render("Warning: "+w))
+				}
+			} else if result.Output != "" {
+				m.history = append(m.history, outputStyle.Render(result.Output))
+
+				// Clear unread notifications when entering a channel
+				if parsedCmd.Type == CmdCd && m.notificationManager != nil {
+					currentChannel := m.executor.GetCurrentChannel()
+					if currentChannel != nil {
+						m.notificationManager.ClearUnread(currentChannel.ID)
+					}
+				}
+			}
 		}
+	}
 
-		if result.Exit {
-			return m, tea.Quit
-		}
+	// Update prompt
+	m.input.SetValue("")
+	m.refreshPrompt()
 
+	return m, nil
+}
+
+// answerConfirm runs or cancels the pending confirmation based on the answer
+func (m *Model) answerConfirm(answer string) (tea.Model, tea.Cmd) {
+	confirm := m.pendingConfirm
+	m.pendingConfirm = nil
+	m.history = append(m.history, confirm.Prompt+answer)
+
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		result := confirm.Action()
 		if result.Error != nil {
 			m.history = append(m.history, errorStyle.Render(FormatError(result.Error)))
-		} else if result.SwitchWorkspace != nil {
-			// Handle workspace switch
-			m.client = result.SwitchWorkspace.Client
-			m.executor.SwitchClient(result.SwitchWorkspace.Client)
-			m.history = append(m.history, outputStyle.Render(
-				"Switched to workspace: "+result.SwitchWorkspace.TeamName))
 		} else if result.Output != "" {
 			m.history = append(m.history, outputStyle.Render(result.Output))
-
-			// Clear unread notifications when entering a channel
-			if parsedCmd.Type == CmdCd && m.notificationManager != nil {
-				currentChannel := m.executor.GetCurrentChannel()
-				if currentChannel != nil {
-					m.notificationManager.ClearUnread(currentChannel.ID)
-				}
-			}
 		}
+	default:
+		m.history = append(m.history, outputStyle.Render("Cancelled."))
 	}
 
-	// Update prompt
 	m.input.SetValue("")
-	m.input.Prompt = promptStyle.Render(m.executor.GetPrompt())
-
+	m.refreshPrompt()
 	return m, nil
 }
 
+// enterModeTarget switches to the channel given as the first argument of
+// browse/live, e.g. "live #general". It reports false after printing the
+// error if the channel can't be entered.
+func (m *Model) enterModeTarget(cmd Command) bool {
+	if len(cmd.Args) == 0 {
+		return true
+	}
+
+	result := m.executor.Execute(Command{Type: CmdCd, Args: cmd.Args[:1], Flags: map[string]string{}})
+	if result.Error != nil {
+		m.history = append(m.history, errorStyle.Render(FormatError(result.Error)))
+		m.input.SetValue("")
+		return false
+	}
+
+	if currentChannel := m.executor.GetCurrentChannel(); currentChannel != nil && m.notificationManager != nil {
+		m.notificationManager.ClearUnread(currentChannel.ID)
+	}
+	m.refreshPrompt()
+	return true
+}
+
 func (m *Model) startBrowseMode(cmd Command) (tea.Model, tea.Cmd) {
+	if !m.enterModeTarget(cmd) {
+		return m, nil
+	}
+
 	currentChannel := m.executor.GetCurrentChannel()
 	if currentChannel == nil {
 		m.history = append(m.history, errorStyle.Render("Not in a channel. Use 'cd #channel' first."))
@@ -443,9 +877,11 @@ func (m *Model) startBrowseMode(cmd Command) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	m.browseModel = NewBrowseModel(m.client, currentChannel.ID, channelName, m.executor.userNames)
+	m.browseModel = NewBrowseModel(m.client, currentChannel.ID, channelName, m.executor.userNames, m.executor.displayConfig, m.keymap)
 	m.browseModel.width = m.width
 	m.browseModel.height = m.height
+	m.browseModel.keywords = m.notificationManager.Keywords()
+	m.browseModel.selfName = m.executor.GetUserName(m.executor.GetCurrentUserID())
 	m.browseMode = true
 	m.input.Blur()
 	m.input.SetValue("")
@@ -454,29 +890,33 @@ func (m *Model) startBrowseMode(cmd Command) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) startLiveMode(cmd Command) (tea.Model, tea.Cmd) {
-	currentChannel := m.executor.GetCurrentChannel()
-	if currentChannel == nil {
-		m.history = append(m.history, errorStyle.Render("Not in a channel. Use 'cd #channel' first."))
+	if m.realtimeClient == nil {
+		m.history = append(m.history, errorStyle.Render("Real-time connection not available. Set SLACK_APP_TOKEN to enable."))
 		m.input.SetValue("")
 		return m, nil
 	}
 
-	if m.realtimeClient == nil {
-		m.history = append(m.history, errorStyle.Render("Real-time connection not available. Set SLACK_APP_TOKEN to enable."))
+	if len(cmd.Args) > 2 {
+		m.history = append(m.history, "Usage: live [#channel] or live #channel1 #channel2")
 		m.input.SetValue("")
 		return m, nil
 	}
+	if len(cmd.Args) == 2 {
+		return m.startSplitLiveMode(cmd)
+	}
 
-	channelName := currentChannel.Name
-	if currentChannel.IsIM {
-		if name, ok := m.executor.userNames[currentChannel.UserID]; ok {
-			channelName = name
-		} else {
-			channelName = currentChannel.UserID
-		}
+	if !m.enterModeTarget(cmd) {
+		return m, nil
 	}
 
-	m.liveModel = NewLiveModel(m.client, currentChannel.ID, channelName, m.executor.userNames, m.executor.displayConfig)
+	currentChannel := m.executor.GetCurrentChannel()
+	if currentChannel == nil {
+		m.history = append(m.history, errorStyle.Render("Not in a channel. Use 'cd #channel' first."))
+		m.input.SetValue("")
+		return m, nil
+	}
+
+	m.liveModel = m.newLiveModel(currentChannel)
 	m.liveModel.width = m.width
 	m.liveModel.height = m.height
 	m.liveMode = true
@@ -486,6 +926,45 @@ func (m *Model) startLiveMode(cmd Command) (tea.Model, tea.Cmd) {
 	return m, m.liveModel.Init()
 }
 
+// startSplitLiveMode shows the two channel arguments side by side,
+// e.g. "live #general #random". The first channel becomes the current one.
+func (m *Model) startSplitLiveMode(cmd Command) (tea.Model, tea.Cmd) {
+	panes := make([]*LiveModel, 2)
+	for i := len(panes) - 1; i >= 0; i-- {
+		if !m.enterModeTarget(Command{Type: CmdLive, Args: cmd.Args[i : i+1]}) {
+			return m, nil
+		}
+		panes[i] = m.newLiveModel(m.executor.GetCurrentChannel())
+	}
+
+	m.splitModel = NewSplitLiveModel(panes...)
+	m.splitModel, _ = m.splitModel.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	m.splitMode = true
+	m.input.Blur()
+	m.input.SetValue("")
+
+	return m, m.splitModel.Init()
+}
+
+// newLiveModel creates a live view of ch, naming DMs after the user
+func (m *Model) newLiveModel(ch *slack.Channel) *LiveModel {
+	channelName := ch.Name
+	if ch.IsIM {
+		if name, ok := m.executor.userNames[ch.UserID]; ok {
+			channelName = name
+		} else {
+			channelName = ch.UserID
+		}
+	}
+
+	live := NewLiveModel(m.client, ch.ID, channelName, m.executor.userNames, m.executor.displayConfig, m.keymap)
+	live.keywords = m.notificationManager.Keywords()
+	live.selfName = m.executor.GetUserName(m.executor.GetCurrentUserID())
+	live.drafts = m.drafts
+	live.profileCache = m.executor.userCache
+	return live
+}
+
 func (m *Model) navigateHistory(direction int) (tea.Model, tea.Cmd) {
 	if len(m.commandHistory) == 0 {
 		return m, nil
@@ -582,6 +1061,9 @@ func (m *Model) View() string {
 	if m.liveMode && m.liveModel != nil {
 		return m.liveModel.View()
 	}
+	if m.splitMode && m.splitModel != nil {
+		return m.splitModel.View()
+	}
 
 	// Browse mode takes over the entire screen
 	if m.browseMode && m.browseModel != nil {
@@ -601,6 +1083,11 @@ func (m *Model) View() string {
 
 	// Calculate how many history lines we can show
 	availableHeight := m.height - 2 - notificationLines // Reserve space for input, padding and notifications
+	if m.composeMode {
+		availableHeight -= m.compose.Height() + 1
+	} else if m.promptHeader != "" {
+		availableHeight -= strings.Count(m.promptHeader, "\n") + 1
+	}
 
 	// Get the history lines to display
 	historyLines := []string{}
@@ -620,12 +1107,39 @@ func (m *Model) View() string {
 		sb.WriteString("\n")
 	}
 
-	// Add input line
+	if m.composeMode {
+		sb.WriteString(modeStyle.Render("Compose message (Ctrl+D: send, Esc: cancel)"))
+		sb.WriteString("\n")
+		sb.WriteString(m.compose.View())
+		return sb.String()
+	}
+
+	// Add input line, prefixed with the realtime connection indicator
+	if m.promptHeader != "" {
+		sb.WriteString(m.promptHeader)
+		sb.WriteString("\n")
+	}
+	sb.WriteString(m.renderConnectionStatus())
 	sb.WriteString(m.input.View())
 
 	return sb.String()
 }
 
+// renderConnectionStatus renders a colored dot showing whether the realtime
+// client is connected. Empty when no app token is configured.
+func (m *Model) renderConnectionStatus() string {
+	if !m.hasAppToken {
+		return ""
+	}
+	if m.connected {
+		return connectedStyle.Render("●") + " "
+	}
+	if m.reconnecting {
+		return reconnectingStyle.Render("●") + " "
+	}
+	return disconnectedStyle.Render("●") + " "
+}
+
 // renderNotifications renders the visual notification area
 func (m *Model) renderNotifications() string {
 	if m.notificationManager == nil {
@@ -647,11 +1161,7 @@ func (m *Model) renderNotifications() string {
 		}
 
 		// Truncate message if too long (use runes for proper multi-byte support)
-		text := n.Text
-		textRunes := []rune(text)
-		if len(textRunes) > 50 {
-			text = string(textRunes[:47]) + "..."
-		}
+		text := truncatePreview(n.Text, m.executor.displayConfig.GetPreviewLength(50))
 
 		line := fmt.Sprintf("%s | %s: %s", prefix, n.UserName, text)
 		lines = append(lines, notificationStyle.Render(line))
@@ -671,6 +1181,18 @@ func (m *Model) HandleRealtimeEvent(event interface{}) tea.Cmd {
 		return func() tea.Msg {
 			return DeletedMessageMsg(e)
 		}
+	case slack.EditedMessage:
+		return func() tea.Msg {
+			return EditedMessageMsg(e)
+		}
+	case slack.ReactionEvent:
+		return func() tea.Msg {
+			return ReactionEventMsg(e)
+		}
+	case slack.TypingEvent:
+		return func() tea.Msg {
+			return TypingEventMsg(e)
+		}
 	case string:
 		if e == "connected" {
 			return func() tea.Msg {
@@ -680,6 +1202,10 @@ func (m *Model) HandleRealtimeEvent(event interface{}) tea.Cmd {
 			return func() tea.Msg {
 				return ConnectionStatusMsg{Connected: false}
 			}
+		} else if e == "reconnecting" {
+			return func() tea.Msg {
+				return ConnectionStatusMsg{Reconnecting: true}
+			}
 		}
 	}
 	return nil
@@ -691,7 +1217,19 @@ type IncomingMessageMsg slack.IncomingMessage
 // DeletedMessageMsg is a message type for deleted Slack messages
 type DeletedMessageMsg slack.DeletedMessage
 
+// EditedMessageMsg is a message type for edited Slack messages
+type EditedMessageMsg slack.EditedMessage
+
+// ReactionEventMsg is a message type for reactions added/removed in realtime
+type ReactionEventMsg slack.ReactionEvent
+
+// TypingEventMsg is a message type for users typing in a channel
+type TypingEventMsg slack.TypingEvent
+
 // ConnectionStatusMsg is a message type for connection status changes
 type ConnectionStatusMsg struct {
-	Connected bool
+	Connected    bool
+	Reconnecting bool
+	// Err is set when the realtime client gave up reconnecting
+	Err error
 }
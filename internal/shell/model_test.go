@@ -0,0 +1,39 @@
+package shell
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/polidog/slack-shell/internal/slack"
+)
+
+func TestViewedChannels(t *testing.T) {
+	executor := &Executor{currentChannel: &slack.Channel{ID: "C0"}}
+	live := &LiveModel{channelID: "C1"}
+	peeking := &LiveModel{channelID: "C1", peekMode: true, peekChannelID: "C2"}
+	split := NewSplitLiveModel(
+		&LiveModel{channelID: "C3"},
+		&LiveModel{channelID: "C4", peekMode: true, peekChannelID: "C5"},
+	)
+
+	tests := []struct {
+		name  string
+		model Model
+		want  []string
+	}{
+		{"shell", Model{executor: executor}, []string{"C0"}},
+		{"browse", Model{executor: executor, browseMode: true, browseModel: &BrowseModel{channelID: "C6"}}, []string{"C6"}},
+		{"live", Model{executor: executor, liveMode: true, liveModel: live}, []string{"C1"}},
+		{"live peek", Model{executor: executor, liveMode: true, liveModel: peeking}, []string{"C1", "C2"}},
+		{"split", Model{executor: executor, splitMode: true, splitModel: split}, []string{"C3", "C4", "C5"}},
+	}
+	for _, tt := range tests {
+		want := make(map[string]bool)
+		for _, id := range tt.want {
+			want[id] = true
+		}
+		if got := tt.model.viewedChannels(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: viewedChannels() = %v; want %v", tt.name, got, want)
+		}
+	}
+}
@@ -3,11 +3,15 @@ package shell
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/kyokomi/emoji/v2"
+	"github.com/polidog/slack-shell/internal/config"
+	"github.com/polidog/slack-shell/internal/notification"
 	"github.com/polidog/slack-shell/internal/slack"
 )
 
@@ -16,8 +20,63 @@ func ConvertEmoji(text string) string {
 	return emoji.Sprint(text)
 }
 
-// FormatChannelList formats a list of channels for display
-func FormatChannelList(channels []slack.Channel, dms []slack.Channel, userNames map[string]string) string {
+// formatReactions renders reactions as "emoji count" pairs
+func formatReactions(reactions []slack.Reaction) string {
+	var parts []string
+	for _, r := range reactions {
+		emojiStr := ConvertEmoji(fmt.Sprintf(":%s:", r.Name))
+		parts = append(parts, fmt.Sprintf("%s %d", emojiStr, r.Count))
+	}
+	return strings.Join(parts, " ")
+}
+
+// maxEmojiCompletions limits how many shortcodes Tab cycles through
+const maxEmojiCompletions = 20
+
+var (
+	emojiNamesOnce sync.Once
+	emojiNames     []string
+)
+
+// EmojiCompletions returns emoji shortcodes (e.g., :smile:) that start with
+// prefix, shortest first
+func EmojiCompletions(prefix string) []string {
+	emojiNamesOnce.Do(func() {
+		for code := range emoji.CodeMap() {
+			emojiNames = append(emojiNames, code)
+		}
+		sort.Slice(emojiNames, func(i, j int) bool {
+			if len(emojiNames[i]) != len(emojiNames[j]) {
+				return len(emojiNames[i]) < len(emojiNames[j])
+			}
+			return emojiNames[i] < emojiNames[j]
+		})
+	})
+
+	prefix = strings.ToLower(prefix)
+	var candidates []string
+	for _, name := range emojiNames {
+		if strings.HasPrefix(name, prefix) {
+			candidates = append(candidates, name)
+			if len(candidates) >= maxEmojiCompletions {
+				break
+			}
+		}
+	}
+	return candidates
+}
+
+// FormatChannelList formats a list of channels for display.
+// unread maps channel IDs to unread counts; counts above zero are shown
+// after the name. It may be nil.
+func FormatChannelList(channels []slack.Channel, dms []slack.Channel, userNames map[string]string, unread map[string]int, format OutputFormat) string {
+	if format == OutputJSON {
+		return marshalJSON(jsonChannelList{
+			Channels: channelsJSON(channels, unread),
+			DMs:      dmsJSON(dms, userNames, unread, nil),
+		})
+	}
+
 	var sb strings.Builder
 
 	if len(channels) > 0 {
@@ -27,7 +86,7 @@ func FormatChannelList(channels []slack.Channel, dms []slack.Channel, userNames
 			if ch.IsPrivate {
 				prefix = "🔒"
 			}
-			sb.WriteString(fmt.Sprintf("  %s %s\n", prefix, ch.Name))
+			sb.WriteString(fmt.Sprintf("  %s %s%s\n", prefix, ch.Name, formatUnread(unread[ch.ID])))
 		}
 	}
 
@@ -41,7 +100,7 @@ func FormatChannelList(channels []slack.Channel, dms []slack.Channel, userNames
 			if userName, ok := userNames[dm.UserID]; ok {
 				name = userName
 			}
-			sb.WriteString(fmt.Sprintf("  @ %s\n", name))
+			sb.WriteString(fmt.Sprintf("  @ %s%s\n", name, formatUnread(unread[dm.ID])))
 		}
 	}
 
@@ -52,8 +111,36 @@ func FormatChannelList(channels []slack.Channel, dms []slack.Channel, userNames
 	return sb.String()
 }
 
-// FormatDMList formats only DMs for display
-func FormatDMList(dms []slack.Channel, userNames map[string]string) string {
+// formatUnread returns " (n)" for a positive unread count, or ""
+func formatUnread(count int) string {
+	if count <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d)", count)
+}
+
+// presenceMarker returns " ●" for an active user, " ○" for an away user
+// and "" if the presence is unknown
+func presenceMarker(presence string) string {
+	switch presence {
+	case "active":
+		return " ●"
+	case "away":
+		return " ○"
+	}
+	return ""
+}
+
+// FormatDMList formats only DMs for display, marking whether each user is
+// active or away (presence maps user IDs to "active" or "away")
+func FormatDMList(dms []slack.Channel, userNames map[string]string, unread map[string]int, presence map[string]string, format OutputFormat) string {
+	if format == OutputJSON {
+		return marshalJSON(jsonChannelList{
+			Channels: []jsonChannel{},
+			DMs:      dmsJSON(dms, userNames, unread, presence),
+		})
+	}
+
 	var sb strings.Builder
 
 	if len(dms) == 0 {
@@ -66,14 +153,85 @@ func FormatDMList(dms []slack.Channel, userNames map[string]string) string {
 		if userName, ok := userNames[dm.UserID]; ok {
 			name = userName
 		}
-		sb.WriteString(fmt.Sprintf("  @ %s\n", name))
+		sb.WriteString(fmt.Sprintf("  @ %s%s%s\n", name, presenceMarker(presence[dm.UserID]), formatUnread(unread[dm.ID])))
 	}
 
 	return sb.String()
 }
 
+// messageUserName returns the display name for a message's author
+func messageUserName(msg slack.Message, userNames map[string]string) string {
+	userName := msg.UserName
+	if userName == "" {
+		if msg.IsBot && msg.BotName != "" {
+			userName = msg.BotName
+		} else if name, ok := userNames[msg.User]; ok {
+			userName = name
+		} else {
+			userName = msg.User
+		}
+	}
+	if userName == "" && msg.IsBot {
+		userName = "bot"
+	}
+	return userName
+}
+
+// FormatMarkdown renders messages as Markdown for export. Thread replies,
+// keyed by parent timestamp, are rendered as quotes under their parent.
+func FormatMarkdown(channelName string, messages []slack.Message, threads map[string][]slack.Message, userNames map[string]string, display *config.DisplayConfig) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# %s\n", channelName))
+
+	for _, msg := range messages {
+		sb.WriteString("\n")
+		writeMarkdownMessage(&sb, msg, userNames, display, "")
+
+		for _, reply := range threads[msg.Timestamp] {
+			sb.WriteString(">\n")
+			writeMarkdownMessage(&sb, reply, userNames, display, "> ")
+		}
+	}
+
+	return sb.String()
+}
+
+// writeMarkdownMessage writes one message with each line prefixed by quote
+func writeMarkdownMessage(sb *strings.Builder, msg slack.Message, userNames map[string]string, display *config.DisplayConfig, quote string) {
+	// Exports always show the full date; only the time zone applies
+	ts := parseTimestamp(msg.Timestamp).In(display.Location()).Format("2006-01-02 15:04")
+	sb.WriteString(fmt.Sprintf("%s**%s** — %s\n", quote, messageUserName(msg, userNames), ts))
+
+	text := ConvertEmoji(ResolveMentions(msg.Text, userNames))
+	for _, line := range strings.Split(text, "\n") {
+		sb.WriteString(quote + line + "\n")
+	}
+	for _, att := range msg.Attachments {
+		if att.Title != "" {
+			sb.WriteString(fmt.Sprintf("%s📎 %s\n", quote, att.Title))
+		}
+	}
+	if len(msg.Reactions) > 0 {
+		sb.WriteString(fmt.Sprintf("%s%s\n", quote, formatReactions(msg.Reactions)))
+	}
+}
+
 // FormatMessages formats a list of messages for display
-func FormatMessages(messages []slack.Message, userNames map[string]string) string {
+// channelNames maps channel IDs to names for <#C123> references; it may be nil.
+// display sets the timestamp format and time zone; it may be nil.
+func FormatMessages(messages []slack.Message, userNames, channelNames map[string]string, display *config.DisplayConfig, format OutputFormat) string {
+	return FormatMessagesWithReplies(messages, nil, userNames, channelNames, display, format)
+}
+
+// FormatMessagesWithReplies formats messages like FormatMessages, showing
+// the latest reply of each thread in latestReplies (keyed by the parent's
+// timestamp) under its thread indicator
+func FormatMessagesWithReplies(messages []slack.Message, latestReplies map[string]slack.Message, userNames, channelNames map[string]string, display *config.DisplayConfig, format OutputFormat) string {
+	if format == OutputJSON {
+		return marshalJSON(messagesJSON(messages, userNames, display))
+	}
+
 	var sb strings.Builder
 
 	if len(messages) == 0 {
@@ -83,25 +241,13 @@ func FormatMessages(messages []slack.Message, userNames map[string]string) strin
 	for _, msg := range messages {
 		// Parse timestamp
 		ts := parseTimestamp(msg.Timestamp)
-		timeStr := ts.Format("15:04")
+		timeStr := display.FormatTime(ts, "15:04")
 
 		// Get user name
-		userName := msg.UserName
-		if userName == "" {
-			if msg.IsBot && msg.BotName != "" {
-				userName = msg.BotName
-			} else if name, ok := userNames[msg.User]; ok {
-				userName = name
-			} else {
-				userName = msg.User
-			}
-		}
-		if userName == "" && msg.IsBot {
-			userName = "bot"
-		}
+		userName := messageUserName(msg, userNames)
 
 		// Resolve mentions in text and convert emoji
-		text := ConvertEmoji(ResolveMentions(msg.Text, userNames))
+		text := ConvertEmoji(StyleMrkdwn(RenderMrkdwn(msg.Text, userNames, channelNames)))
 
 		// Format the message
 		sb.WriteString(fmt.Sprintf("[%s] %s: %s\n", timeStr, userName, text))
@@ -118,23 +264,43 @@ func FormatMessages(messages []slack.Message, userNames map[string]string) strin
 
 		// Show reactions
 		if len(msg.Reactions) > 0 {
-			var reactions []string
-			for _, r := range msg.Reactions {
-				emojiStr := ConvertEmoji(fmt.Sprintf(":%s:", r.Name))
-				reactions = append(reactions, fmt.Sprintf("%s %d", emojiStr, r.Count))
-			}
-			sb.WriteString(fmt.Sprintf("        %s\n", strings.Join(reactions, " ")))
+			sb.WriteString(fmt.Sprintf("        %s\n", formatReactions(msg.Reactions)))
 		}
 
 		// Show thread indicator
 		if msg.ReplyCount > 0 {
 			sb.WriteString(fmt.Sprintf("        └─ %d replies\n", msg.ReplyCount))
+			if reply, ok := latestReplies[msg.Timestamp]; ok {
+				replyTime := display.FormatTime(parseTimestamp(reply.Timestamp), "15:04")
+				replyText := ConvertEmoji(StyleMrkdwn(RenderMrkdwn(reply.Text, userNames, channelNames)))
+				sb.WriteString(fmt.Sprintf("           [%s] %s: %s\n", replyTime, messageUserName(reply, userNames), replyText))
+			}
 		}
 	}
 
 	return sb.String()
 }
 
+// FormatThread formats a thread for display: the parent message followed by
+// its replies, indented
+func FormatThread(messages []slack.Message, userNames, channelNames map[string]string, display *config.DisplayConfig, format OutputFormat) string {
+	if format == OutputJSON {
+		return marshalJSON(messagesJSON(messages, userNames, display))
+	}
+
+	if len(messages) == 0 {
+		return "No messages."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(FormatMessages(messages[:1], userNames, channelNames, display, OutputText))
+	replies := strings.TrimRight(FormatMessages(messages[1:], userNames, channelNames, display, OutputText), "\n")
+	for _, line := range strings.Split(replies, "\n") {
+		sb.WriteString("  " + line + "\n")
+	}
+	return sb.String()
+}
+
 // FormatHelp returns the help text
 func FormatHelp() string {
 	return `Available commands:
@@ -142,20 +308,58 @@ func FormatHelp() string {
   ls              List channels and DMs (uses cache)
   ls -r           List channels and DMs (refresh cache)
   ls dm           List DMs only
-  cd #channel     Enter a channel
+  ls --sort name  Sort alphabetically (--sort unread: unread first)
+  refresh         Clear cached channels, DMs and users (alias: reload)
+  mute [#channel] Mute notifications for a channel (default: current)
+  unmute [#channel]  Unmute notifications for a channel
+  dnd [on|off]    Show or set Do Not Disturb for this client only
+  dnd slack [minutes|off]  Show or set Slack's Do Not Disturb (all devices)
+  notify test     Send a test notification through every notifier
+  cd #channel     Enter a channel (non-member public channels open read-only)
+  cd --join #channel  Join a public channel and enter it
   cd @user        Enter a DM
+  cd -            Return to the previous channel
+  pushd #channel  Enter a channel, remembering the current one
+  popd            Return to the channel saved by pushd
+  dirs            Show the channel stack
   ..              Go back to channel list
   mkdir #channel  Create a public channel
   mkdir -p #chan  Create a private channel
   cat             Show messages (default 20)
   cat -n 50       Show 50 messages
+  cat -n 10 --from 50  Show 10 messages, skipping the 50 most recent
+  cat --threads   Also show the latest reply of each thread
   show            Show channel info and members (default 20)
   show -n 50      Show channel info with 50 members
-  browse          Interactive message browser
-                  (j/k: navigate, Enter: view thread, r: reply, q: exit)
-  live            Live mode with real-time updates and message sending
-                  (i: new message, Enter: view thread, r: reply, j/k: navigate, q: exit)
+  members         List channel members, or both people in a DM (-n N to limit)
+  users NAME      Search the workspace for users (also: find -u NAME, -n N to limit)
+  thread N        Show the thread of the Nth most recent message
+  link [N]        Print the link to the Nth most recent message (default: latest)
+  open [#ch|@user]  Open the current (or given) channel in the browser or Slack app
+  browse [#ch]    Interactive message browser
+                  (j/k: navigate, Enter: view thread, r: reply, /: search, q: exit)
+  live [#ch]      Live mode with real-time updates and message sending
+                  (i: new message, Enter: view thread, r: reply, /: search, j/k: navigate, q: exit)
+  live #a #b      Watch two channels side by side (Tab: switch pane)
   send <message>  Send a message
+  send --reply-to N <message>  Reply in the thread of the Nth most recent message
+  send --thread <ts> <message> Reply in the thread with the given timestamp
+                  (use \n for a newline and \t for a tab in messages)
+  send -m         Compose a multi-line message (Ctrl+D: send, Esc: cancel)
+  edit <text>     Edit your latest message
+  edit -n 2 <text>  Edit the 2nd most recent message (must be yours; also: edit 2 <text>)
+  edit -n 2       Print the message's current text
+  reply -n 1 <message>  Reply in the thread of the latest message
+  pin [-n N]      Pin the Nth most recent message (default: latest)
+  unpin [-n N]    Unpin the Nth most recent message (default: latest)
+  pins            List pinned messages in the current channel
+  rm              Delete your latest message (asks for confirmation)
+  rm -n 2 --yes   Delete the 2nd most recent message without asking
+  status          Show your custom status
+  status <text> [:emoji:] [1h]  Set your custom status (optional emoji/expiry)
+  status clear    Clear your custom status
+  status away     Set presence to away (status auto: back to automatic)
+  export [-n N] [file]  Export messages and threads as Markdown
   pwd             Show current channel
   source <file>   Switch workspace using config file
   help            Show this help
@@ -167,16 +371,26 @@ Admin commands:
   sudo app remove               Leave all public channels
   sudo app remove #ch1 #ch2     Leave specific channels
   whoami                        Show current authentication info
+  auth status                   Check tokens against Slack and show granted scopes
+  connection                    Show Socket Mode status (alias: uptime)
 
 Pipe support:
   ls | grep <pattern>     Search channels/DMs by name
   cat | grep <pattern>    Search messages by content
 
+Command chaining:
+  cd #general && cat      Run cat only if cd succeeded
+  cd #general ; cat       Run both regardless of errors
+  send "a; b"             Quote text containing ; or && (send a; b sends "a" and runs b)
+
 Keyboard shortcuts:
   Ctrl+L                  Refresh screen
+  Ctrl+N                  Dismiss notifications
   Ctrl+C                  Exit application
   Tab                     Auto-complete
   Up/Down                 Navigate command history
+  Ctrl+A / Ctrl+E         Move to start/end of line
+  Ctrl+W / Ctrl+K         Delete previous word / to end of line
 `
 }
 
@@ -185,13 +399,48 @@ func FormatError(err error) string {
 	return fmt.Sprintf("Error: %s", err.Error())
 }
 
+// mentionsUser reports whether text mentions userID directly (<@ID> or
+// <@ID|name>) or through @here, @channel or @everyone
+func mentionsUser(text, userID string) bool {
+	if specialMentionRe.MatchString(text) {
+		return true
+	}
+	return userID != "" &&
+		(strings.Contains(text, "<@"+userID+">") || strings.Contains(text, "<@"+userID+"|"))
+}
+
+// keywordMarker returns a marker for messages matching a notification
+// keyword, or "" otherwise
+func keywordMarker(keywords *notification.KeywordMatcher, text string) string {
+	if keywords.Match(text) {
+		return "★ "
+	}
+	return ""
+}
+
 // FormatSuccess formats a success message
 func FormatSuccess(msg string) string {
 	return msg
 }
 
-// ResolveMentions replaces <@USER_ID> patterns with @username
+var (
+	specialMentionRe = regexp.MustCompile(`<!(here|channel|everyone)(?:\|[^>]*)?>`)
+	subteamMentionRe = regexp.MustCompile(`<!subteam\^([A-Z0-9]+)(?:\|([^>]*))?>`)
+)
+
+// ResolveMentions replaces <@USER_ID> patterns with @username, user group
+// mentions (<!subteam^ID|@handle>) with @handle and <!here>, <!channel> and
+// <!everyone> with their plain forms
 func ResolveMentions(text string, userNames map[string]string) string {
+	text = specialMentionRe.ReplaceAllString(text, "@$1")
+	text = subteamMentionRe.ReplaceAllStringFunc(text, func(match string) string {
+		m := subteamMentionRe.FindStringSubmatch(match)
+		if handle := strings.TrimPrefix(m[2], "@"); handle != "" {
+			return "@" + handle
+		}
+		return "@" + m[1]
+	})
+
 	// Match <@U12345> or <@U12345|display_name> patterns
 	re := regexp.MustCompile(`<@([A-Z0-9]+)(?:\|[^>]*)?>`)
 	return re.ReplaceAllStringFunc(text, func(match string) string {
@@ -207,6 +456,67 @@ func ResolveMentions(text string, userNames map[string]string) string {
 	})
 }
 
+var (
+	mrkdwnChannelRe = regexp.MustCompile(`<#([A-Z0-9]+)(?:\|([^>]*))?>`)
+	mrkdwnLinkRe    = regexp.MustCompile(`<((?:https?|mailto):[^|>]+)(?:\|([^>]*))?>`)
+	mrkdwnBoldRe    = regexp.MustCompile(`(^|[\s(])\*([^*\n]+)\*`)
+	mrkdwnItalicRe  = regexp.MustCompile(`(^|[\s(])_([^_\n]+)_`)
+	mrkdwnStrikeRe  = regexp.MustCompile(`(^|[\s(])~([^~\n]+)~`)
+)
+
+// RenderMrkdwn converts Slack markup to plain text: user mentions become
+// @name, channel references become #name, links become "label (url)" (or just
+// the url when there is no label) and HTML entities are unescaped.
+// channelNames maps channel IDs to names and may be nil, in which case the
+// name embedded in the reference is used.
+func RenderMrkdwn(text string, userNames, channelNames map[string]string) string {
+	text = ResolveMentions(text, userNames)
+
+	text = mrkdwnChannelRe.ReplaceAllStringFunc(text, func(match string) string {
+		m := mrkdwnChannelRe.FindStringSubmatch(match)
+		if name, ok := channelNames[m[1]]; ok {
+			return "#" + name
+		}
+		if m[2] != "" {
+			return "#" + m[2]
+		}
+		return "#" + m[1]
+	})
+
+	text = mrkdwnLinkRe.ReplaceAllStringFunc(text, func(match string) string {
+		m := mrkdwnLinkRe.FindStringSubmatch(match)
+		url := strings.TrimPrefix(m[1], "mailto:")
+		if m[2] == "" || m[2] == url {
+			return url
+		}
+		return fmt.Sprintf("%s (%s)", m[2], url)
+	})
+
+	// Slack escapes these three characters in message text
+	return strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&").Replace(text)
+}
+
+// StyleMrkdwn renders *bold*, _italic_ and ~strike~ spans with terminal styles
+func StyleMrkdwn(text string) string {
+	spans := []struct {
+		re    *regexp.Regexp
+		style lipgloss.Style
+	}{
+		{mrkdwnBoldRe, lipgloss.NewStyle().Bold(true)},
+		{mrkdwnItalicRe, lipgloss.NewStyle().Italic(true)},
+		{mrkdwnStrikeRe, lipgloss.NewStyle().Strikethrough(true)},
+	}
+	for _, s := range spans {
+		style := s.style
+		re := s.re
+		text = re.ReplaceAllStringFunc(text, func(match string) string {
+			m := re.FindStringSubmatch(match)
+			return m[1] + style.Render(m[2])
+		})
+	}
+	return text
+}
+
 func parseTimestamp(ts string) time.Time {
 	// Slack timestamps are in format "1234567890.123456"
 	var sec int64
@@ -220,36 +530,21 @@ func parseTimestamp(ts string) time.Time {
 }
 
 // FormatChannelInfo formats channel information for display
-func FormatChannelInfo(info *slack.ChannelInfo, memberIDs []string, userNames map[string]string, creatorName string, memberLimit int) string {
-	var sb strings.Builder
-
-	// Define styles using ANSI colors (adapts to terminal theme)
-	titleStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("14")) // bright cyan
-
-	privateStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("11")) // bright yellow
-
-	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("8")) // bright black (gray)
-
-	valueStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("15")) // bright white
-
-	accentStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("10")) // bright green
-
-	userStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("9")) // bright red
+func FormatChannelInfo(info *slack.ChannelInfo, memberIDs []string, userNames map[string]string, creatorName string, memberLimit int, format OutputFormat) string {
+	if format == OutputJSON {
+		return marshalJSON(channelInfoJSON(info, memberIDs, userNames, creatorName))
+	}
 
-	mutedStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("8")) // bright black (gray)
+	var sb strings.Builder
 
-	sectionStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("13")) // bright magenta
+	titleStyle := channelInfoTitleStyle
+	privateStyle := channelInfoPrivateStyle
+	labelStyle := channelInfoLabelStyle
+	valueStyle := channelInfoValueStyle
+	accentStyle := channelInfoCountStyle
+	userStyle := channelInfoUserStyle
+	mutedStyle := channelInfoLabelStyle
+	sectionStyle := channelInfoSectionStyle
 
 	// Channel name and type
 	if info.IsPrivate {
@@ -304,3 +599,46 @@ func FormatChannelInfo(info *slack.ChannelInfo, memberIDs []string, userNames ma
 
 	return sb.String()
 }
+
+// FormatUsers formats user search results: handle, display name, real
+// name and ID, tab-separated for piping
+func FormatUsers(users []slack.User, format OutputFormat) string {
+	if format == OutputJSON {
+		return marshalJSON(usersJSON(users))
+	}
+
+	if len(users) == 0 {
+		return "No users found."
+	}
+
+	var sb strings.Builder
+	for _, u := range users {
+		name := "@" + u.Name
+		if u.IsBot {
+			name += " [bot]"
+		}
+		sb.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\n", name, u.DisplayName, u.RealName, u.ID))
+	}
+	return sb.String()
+}
+
+// FormatMembers formats a channel member list for display
+func FormatMembers(memberIDs []string, userNames map[string]string, format OutputFormat) string {
+	if format == OutputJSON {
+		return marshalJSON(membersJSON(memberIDs, userNames))
+	}
+
+	if len(memberIDs) == 0 {
+		return "No members."
+	}
+
+	var sb strings.Builder
+	for _, id := range memberIDs {
+		name := id
+		if userName, ok := userNames[id]; ok {
+			name = userName
+		}
+		sb.WriteString("@" + name + "\n")
+	}
+	return sb.String()
+}
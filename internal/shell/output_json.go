@@ -0,0 +1,185 @@
+package shell
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/polidog/slack-shell/internal/config"
+	"github.com/polidog/slack-shell/internal/slack"
+)
+
+// OutputFormat selects how command output is rendered
+type OutputFormat int
+
+const (
+	// OutputText is human-readable text (the default)
+	OutputText OutputFormat = iota
+	// OutputJSON is structured JSON for scripts
+	OutputJSON
+)
+
+type jsonChannel struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Private bool   `json:"private"`
+	Unread  int    `json:"unread"`
+}
+
+type jsonDM struct {
+	ID       string `json:"id"`
+	UserID   string `json:"user_id"`
+	Name     string `json:"name"`
+	Unread   int    `json:"unread"`
+	Presence string `json:"presence,omitempty"`
+}
+
+type jsonChannelList struct {
+	Channels []jsonChannel `json:"channels"`
+	DMs      []jsonDM      `json:"dms"`
+}
+
+type jsonReaction struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type jsonMessage struct {
+	Timestamp  string         `json:"ts"`
+	Time       string         `json:"time"`
+	UserID     string         `json:"user_id,omitempty"`
+	User       string         `json:"user"`
+	Text       string         `json:"text"`
+	ThreadTS   string         `json:"thread_ts,omitempty"`
+	ReplyCount int            `json:"reply_count"`
+	Reactions  []jsonReaction `json:"reactions,omitempty"`
+}
+
+type jsonMember struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type jsonUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	RealName    string `json:"real_name"`
+	Bot         bool   `json:"bot"`
+}
+
+type jsonChannelInfo struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Topic       string       `json:"topic"`
+	Purpose     string       `json:"purpose"`
+	Created     string       `json:"created"`
+	Creator     string       `json:"creator"`
+	Private     bool         `json:"private"`
+	Archived    bool         `json:"archived"`
+	General     bool         `json:"general"`
+	MemberCount int          `json:"member_count"`
+	Members     []jsonMember `json:"members"`
+}
+
+// marshalJSON renders v as indented JSON
+func marshalJSON(v interface{}) string {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
+
+func channelsJSON(channels []slack.Channel, unread map[string]int) []jsonChannel {
+	result := make([]jsonChannel, 0, len(channels))
+	for _, ch := range channels {
+		result = append(result, jsonChannel{
+			ID:      ch.ID,
+			Name:    ch.Name,
+			Private: ch.IsPrivate,
+			Unread:  unread[ch.ID],
+		})
+	}
+	return result
+}
+
+func dmsJSON(dms []slack.Channel, userNames map[string]string, unread map[string]int, presence map[string]string) []jsonDM {
+	result := make([]jsonDM, 0, len(dms))
+	for _, dm := range dms {
+		name := dm.UserID
+		if userName, ok := userNames[dm.UserID]; ok {
+			name = userName
+		}
+		result = append(result, jsonDM{
+			ID:       dm.ID,
+			UserID:   dm.UserID,
+			Name:     name,
+			Unread:   unread[dm.ID],
+			Presence: presence[dm.UserID],
+		})
+	}
+	return result
+}
+
+func messagesJSON(messages []slack.Message, userNames map[string]string, display *config.DisplayConfig) []jsonMessage {
+	result := make([]jsonMessage, 0, len(messages))
+	for _, msg := range messages {
+		m := jsonMessage{
+			Timestamp:  msg.Timestamp,
+			Time:       parseTimestamp(msg.Timestamp).In(display.Location()).Format(time.RFC3339),
+			UserID:     msg.User,
+			User:       messageUserName(msg, userNames),
+			Text:       ResolveMentions(msg.Text, userNames),
+			ThreadTS:   msg.ThreadTS,
+			ReplyCount: msg.ReplyCount,
+		}
+		for _, r := range msg.Reactions {
+			m.Reactions = append(m.Reactions, jsonReaction{Name: r.Name, Count: r.Count})
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+func usersJSON(users []slack.User) []jsonUser {
+	result := make([]jsonUser, 0, len(users))
+	for _, u := range users {
+		result = append(result, jsonUser{
+			ID:          u.ID,
+			Name:        u.Name,
+			DisplayName: u.DisplayName,
+			RealName:    u.RealName,
+			Bot:         u.IsBot,
+		})
+	}
+	return result
+}
+
+func membersJSON(memberIDs []string, userNames map[string]string) []jsonMember {
+	result := make([]jsonMember, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		name := id
+		if userName, ok := userNames[id]; ok {
+			name = userName
+		}
+		result = append(result, jsonMember{ID: id, Name: name})
+	}
+	return result
+}
+
+func channelInfoJSON(info *slack.ChannelInfo, memberIDs []string, userNames map[string]string, creatorName string) jsonChannelInfo {
+	return jsonChannelInfo{
+		ID:          info.ID,
+		Name:        info.Name,
+		Topic:       info.Topic,
+		Purpose:     info.Purpose,
+		Created:     time.Unix(info.Created, 0).Format(time.RFC3339),
+		Creator:     creatorName,
+		Private:     info.IsPrivate,
+		Archived:    info.IsArchived,
+		General:     info.IsGeneral,
+		MemberCount: info.MemberCount,
+		Members:     membersJSON(memberIDs, userNames),
+	}
+}
@@ -0,0 +1,137 @@
+package shell
+
+import (
+	"testing"
+
+	"github.com/polidog/slack-shell/internal/config"
+	"github.com/polidog/slack-shell/internal/slack"
+)
+
+func TestRenderMrkdwn(t *testing.T) {
+	userNames := map[string]string{"U123": "alice"}
+	channelNames := map[string]string{"C123": "general"}
+
+	tests := []struct {
+		text, want string
+	}{
+		{"see <https://example.com|the docs>", "see the docs (https://example.com)"},
+		{"see <https://example.com>", "see https://example.com"},
+		{"<https://example.com|https://example.com>", "https://example.com"},
+		{"mail <mailto:a@example.com|a@example.com>", "mail a@example.com"},
+		{"join <#C123>", "join #general"},
+		{"join <#C123|old-name>", "join #general"},
+		{"join <#C999|random>", "join #random"},
+		{"join <#C999>", "join #C999"},
+		{"<!here> ping <@U123>", "@here ping @alice"},
+		{"a &lt;b&gt; &amp; c", "a <b> & c"},
+	}
+
+	for _, tt := range tests {
+		if got := RenderMrkdwn(tt.text, userNames, channelNames); got != tt.want {
+			t.Errorf("RenderMrkdwn(%q) = %q; want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestResolveMentions(t *testing.T) {
+	userNames := map[string]string{"U123": "alice"}
+
+	tests := []struct {
+		text, want string
+	}{
+		{"hi <@U123>", "hi @alice"},
+		{"hi <@U999>", "hi <@U999>"},
+		{"<!subteam^S123|@devs> please review", "@devs please review"},
+		{"<!subteam^S123> please review", "@S123 please review"},
+		{"<!here> <!channel> <!everyone>", "@here @channel @everyone"},
+		{"<!here|here> standup", "@here standup"},
+	}
+
+	for _, tt := range tests {
+		if got := ResolveMentions(tt.text, userNames); got != tt.want {
+			t.Errorf("ResolveMentions(%q) = %q; want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMentionsUser(t *testing.T) {
+	tests := []struct {
+		text   string
+		userID string
+		want   bool
+	}{
+		{"hi <@U123>", "U123", true},
+		{"hi <@U123|alice>", "U123", true},
+		{"hi <@U1234>", "U123", false},
+		{"hi <@U999>", "U123", false},
+		{"<!here> standup", "U123", true},
+		{"<!channel|channel> deploy", "U123", true},
+		{"<!everyone>", "U123", true},
+		{"<!subteam^S123|@devs> review", "U123", false},
+		{"mail me at here@example.com", "U123", false},
+		{"hi <@U123>", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := mentionsUser(tt.text, tt.userID); got != tt.want {
+			t.Errorf("mentionsUser(%q, %q) = %v; want %v", tt.text, tt.userID, got, tt.want)
+		}
+	}
+}
+
+func TestSplitPrompt(t *testing.T) {
+	tests := []struct {
+		prompt, header, line string
+	}{
+		{"ws #general> ", "", "ws #general> "},
+		{"ws #general{newline}> ", "ws #general", "> "},
+		{"ws{newline}#general{newline}> ", "ws{newline}#general", "> "},
+	}
+	for _, tt := range tests {
+		header, line := splitPrompt(tt.prompt)
+		if header != tt.header || line != tt.line {
+			t.Errorf("splitPrompt(%q) = %q, %q; want %q, %q", tt.prompt, header, line, tt.header, tt.line)
+		}
+	}
+}
+
+func TestPlainPrompt(t *testing.T) {
+	tests := []struct {
+		prompt, want string
+	}{
+		{"{green}work{reset} #general> ", "work #general> "},
+		{"{bold}{cyan}ws{reset}> ", "ws> "},
+		{"{unknown}ws> ", "{unknown}ws> "},
+		{"ws> ", "ws> "},
+		{"{green}ws #general{newline}{bold}> ", "ws #general\n> "},
+	}
+	for _, tt := range tests {
+		if got := plainPrompt(tt.prompt); got != tt.want {
+			t.Errorf("plainPrompt(%q) = %q; want %q", tt.prompt, got, tt.want)
+		}
+	}
+}
+
+func TestFormatMessagesWithReplies(t *testing.T) {
+	messages := []slack.Message{
+		{Timestamp: "1700000000.000100", User: "U1", Text: "release today?", ReplyCount: 150, LatestReply: "1700000600.000200"},
+		{Timestamp: "1700000100.000100", User: "U2", Text: "no thread"},
+		{Timestamp: "1700000200.000100", User: "U2", Text: "unloaded thread", ReplyCount: 2},
+	}
+	latest := map[string]slack.Message{
+		"1700000000.000100": {Timestamp: "1700000600.000200", User: "U2", Text: "yes, <@U1>"},
+	}
+	names := map[string]string{"U1": "alice", "U2": "bob"}
+	display := &config.DisplayConfig{Timezone: "UTC"}
+
+	got := FormatMessagesWithReplies(messages, latest, names, nil, display, OutputText)
+	want := "[22:13] alice: release today?\n" +
+		"        └─ 150 replies\n" +
+		"           [22:23] bob: yes, @alice\n" +
+		"[22:15] bob: no thread\n" +
+		"[22:16] bob: unloaded thread\n" +
+		"        └─ 2 replies\n"
+	if got != want {
+		t.Errorf("FormatMessagesWithReplies() =\n%s\nwant\n%s", got, want)
+	}
+}
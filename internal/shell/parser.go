@@ -1,6 +1,7 @@
 package shell
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -27,6 +28,29 @@ const (
 	CmdSudo
 	CmdWhoami
 	CmdShow
+	CmdEdit
+	CmdRm
+	CmdReply
+	CmdPin
+	CmdUnpin
+	CmdPins
+	CmdStatus
+	CmdExport
+	CmdMembers
+	CmdThread
+	CmdRefresh
+	CmdMute
+	CmdUnmute
+	CmdDnd
+	CmdConnection
+	CmdNotify
+	CmdAuth
+	CmdUsers
+	CmdPushd
+	CmdPopd
+	CmdDirs
+	CmdLink
+	CmdOpen
 )
 
 // Pipeline represents a series of commands connected by pipes
@@ -68,9 +92,15 @@ func ParseCommand(input string) Command {
 	// Parse remaining parts as flags and arguments
 	for i := 1; i < len(parts); i++ {
 		part := parts[i]
-		if strings.HasPrefix(part, "-") {
+		// A lone "-" is an argument, as in "cd -"
+		if strings.HasPrefix(part, "-") && part != "-" {
 			// It's a flag
 			flagName := strings.TrimLeft(part, "-")
+			// --flag=value takes the value as-is, even if it starts with "-"
+			if name, value, ok := strings.Cut(flagName, "="); ok {
+				cmd.Flags[name] = value
+				continue
+			}
 			// Check if next part is the flag value
 			if i+1 < len(parts) && !strings.HasPrefix(parts[i+1], "-") {
 				cmd.Flags[flagName] = parts[i+1]
@@ -130,6 +160,52 @@ func parseCommandType(s string) CommandType {
 		return CmdWhoami
 	case "show":
 		return CmdShow
+	case "edit":
+		return CmdEdit
+	case "rm":
+		return CmdRm
+	case "reply":
+		return CmdReply
+	case "pin":
+		return CmdPin
+	case "unpin":
+		return CmdUnpin
+	case "pins":
+		return CmdPins
+	case "status":
+		return CmdStatus
+	case "export":
+		return CmdExport
+	case "members":
+		return CmdMembers
+	case "thread":
+		return CmdThread
+	case "refresh", "reload":
+		return CmdRefresh
+	case "mute":
+		return CmdMute
+	case "unmute":
+		return CmdUnmute
+	case "dnd":
+		return CmdDnd
+	case "connection", "uptime":
+		return CmdConnection
+	case "notify":
+		return CmdNotify
+	case "auth":
+		return CmdAuth
+	case "users", "find":
+		return CmdUsers
+	case "pushd":
+		return CmdPushd
+	case "popd":
+		return CmdPopd
+	case "dirs":
+		return CmdDirs
+	case "link":
+		return CmdLink
+	case "open":
+		return CmdOpen
 	default:
 		return CmdUnknown
 	}
@@ -154,6 +230,65 @@ func ParsePipeline(input string) Pipeline {
 	return pipeline
 }
 
+// ChainedCommand is one command of a line joined with && or ;
+type ChainedCommand struct {
+	Input string
+	// Conditional is true when the command follows && and should only run
+	// if the previous command succeeded
+	Conditional bool
+}
+
+// SplitCommands splits a command line by && and ; but respects quotes
+func SplitCommands(input string) []ChainedCommand {
+	var result []ChainedCommand
+	var current strings.Builder
+	conditional := false
+	inQuote := false
+	quoteChar := rune(0)
+
+	for i, r := range input {
+		if (r == '"' || r == '\'') && (i == 0 || input[i-1] != '\\') {
+			if !inQuote {
+				inQuote = true
+				quoteChar = r
+			} else if r == quoteChar {
+				inQuote = false
+			}
+			current.WriteRune(r)
+			continue
+		}
+
+		if !inQuote {
+			// Check for &&
+			if r == '&' && i+1 < len(input) && input[i+1] == '&' {
+				result = append(result, ChainedCommand{Input: current.String(), Conditional: conditional})
+				current.Reset()
+				conditional = true
+				continue
+			}
+			// Skip the second &
+			if r == '&' && i > 0 && input[i-1] == '&' {
+				continue
+			}
+			// Check for ;
+			if r == ';' {
+				result = append(result, ChainedCommand{Input: current.String(), Conditional: conditional})
+				current.Reset()
+				conditional = false
+				continue
+			}
+		}
+
+		current.WriteRune(r)
+	}
+
+	if current.Len() > 0 {
+		result = append(result, ChainedCommand{Input: current.String(), Conditional: conditional})
+	}
+
+	return result
+}
+
 // splitByPipe splits input by | but respects quotes
 func splitByPipe(input string) []string {
 	var parts []string
@@ -238,6 +373,28 @@ func tokenize(input string) []string {
 	return tokens
 }
 
+// trimLeadingFlags removes the given flags, and their values, from the start
+// of raw args, leaving the rest of the text exactly as typed
+func trimLeadingFlags(raw string, names ...string) string {
+	for {
+		raw = strings.TrimLeft(raw, " ")
+		token, rest, _ := strings.Cut(raw, " ")
+		name, _, hasValue := strings.Cut(strings.TrimLeft(token, "-"), "=")
+		if !strings.HasPrefix(token, "-") || !slices.Contains(names, name) {
+			return raw
+		}
+		raw = rest
+		if !hasValue {
+			// Skip the flag's value, as ParseCommand does
+			rest = strings.TrimLeft(rest, " ")
+			value, after, _ := strings.Cut(rest, " ")
+			if value != "" && !strings.HasPrefix(value, "-") {
+				raw = after
+			}
+		}
+	}
+}
+
 // GetFlagInt returns the integer value of a flag, or the default if not set or invalid
 func (c *Command) GetFlagInt(name string, defaultVal int) int {
 	if val, ok := c.Flags[name]; ok {
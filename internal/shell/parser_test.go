@@ -0,0 +1,64 @@
+package shell
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommandFlags(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantFlags map[string]string
+		wantArgs  []string
+	}{
+		{"cat -n 5", map[string]string{"n": "5"}, []string{}},
+		{"cat -n=5", map[string]string{"n": "5"}, []string{}},
+		{"ls --sort=unread dm", map[string]string{"sort": "unread"}, []string{"dm"}},
+		{"send --thread=-1 hi", map[string]string{"thread": "-1"}, []string{"hi"}},
+		{`send --comment="hello world" hi`, map[string]string{"comment": "hello world"}, []string{"hi"}},
+		{"send --comment= hi", map[string]string{"comment": ""}, []string{"hi"}},
+		{"rm -n 2 --yes", map[string]string{"n": "2", "yes": "true"}, []string{}},
+		{"cat -n -1", map[string]string{"n": "true", "1": "true"}, []string{}},
+		{"cd -", map[string]string{}, []string{"-"}},
+	}
+
+	for _, tt := range tests {
+		cmd := ParseCommand(tt.input)
+		if !reflect.DeepEqual(cmd.Flags, tt.wantFlags) {
+			t.Errorf("ParseCommand(%q).Flags = %v; want %v", tt.input, cmd.Flags, tt.wantFlags)
+		}
+		if !reflect.DeepEqual(cmd.Args, tt.wantArgs) {
+			t.Errorf("ParseCommand(%q).Args = %v; want %v", tt.input, cmd.Args, tt.wantArgs)
+		}
+	}
+}
+
+func TestSplitCommands(t *testing.T) {
+	got := SplitCommands(`cd #general && send "a && b; c" ; cat`)
+	want := []ChainedCommand{
+		{Input: "cd #general ", Conditional: false},
+		{Input: ` send "a && b; c" `, Conditional: true},
+		{Input: " cat", Conditional: false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitCommands = %#v; want %#v", got, want)
+	}
+}
+
+func TestTrimLeadingFlags(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"--reply-to 2 hello   world", "hello   world"},
+		{"--thread=1712345678.000100 hi", "hi"},
+		{"--reply-to 1 --thread 123.4 a -b  c", "a -b  c"},
+		{`--reply-to 3 "quoted"  text`, `"quoted"  text`},
+		{"hello --reply-to 2", "hello --reply-to 2"},
+		{"--other 1 hi", "--other 1 hi"},
+	}
+	for _, tt := range tests {
+		if got := trimLeadingFlags(tt.in, "reply-to", "thread"); got != tt.want {
+			t.Errorf("trimLeadingFlags(%q) = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+}
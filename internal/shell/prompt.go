@@ -0,0 +1,99 @@
+package shell
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/polidog/slack-shell/internal/ui/styles"
+)
+
+// promptColors are the color names usable as {name} tokens in the prompt
+// format, mapped to ANSI colors
+var promptColors = map[string]string{
+	"black":   "0",
+	"red":     "1",
+	"green":   "2",
+	"yellow":  "3",
+	"blue":    "4",
+	"magenta": "5",
+	"cyan":    "6",
+	"white":   "7",
+	"gray":    "8",
+}
+
+// promptTokenPattern matches {name} tokens left in a formatted prompt
+var promptTokenPattern = regexp.MustCompile(`\{[a-z]+\}`)
+
+// promptNewline splits the prompt into lines. The last line is shown as the
+// input's prompt and the lines before it above the input.
+const promptNewline = "{newline}"
+
+// renderPrompt renders a formatted prompt with base, switching style at
+// {colorname}, {bold} and {reset} tokens. Unknown tokens are kept as text.
+// Each {newline}-separated line starts again with base.
+func renderPrompt(prompt string, base lipgloss.Style) string {
+	lines := strings.Split(prompt, promptNewline)
+	for i, line := range lines {
+		lines[i] = renderPromptLine(line, base)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitPrompt separates the lines shown above the input from the input's
+// own prompt line
+func splitPrompt(prompt string) (header, line string) {
+	i := strings.LastIndex(prompt, promptNewline)
+	if i < 0 {
+		return "", prompt
+	}
+	return prompt[:i], prompt[i+len(promptNewline):]
+}
+
+func renderPromptLine(prompt string, base lipgloss.Style) string {
+	var sb strings.Builder
+	style := base
+	last := 0
+	for _, loc := range promptTokenPattern.FindAllStringIndex(prompt, -1) {
+		name := prompt[loc[0]+1 : loc[1]-1]
+		next, ok := promptTokenStyle(name, style, base)
+		if !ok {
+			continue
+		}
+		if last < loc[0] {
+			sb.WriteString(style.Render(prompt[last:loc[0]]))
+		}
+		style = next
+		last = loc[1]
+	}
+	if last < len(prompt) {
+		sb.WriteString(style.Render(prompt[last:]))
+	}
+	return sb.String()
+}
+
+// plainPrompt removes the style tokens from a formatted prompt
+func plainPrompt(prompt string) string {
+	prompt = strings.ReplaceAll(prompt, promptNewline, "\n")
+	return promptTokenPattern.ReplaceAllStringFunc(prompt, func(token string) string {
+		if _, ok := promptTokenStyle(token[1:len(token)-1], lipgloss.Style{}, lipgloss.Style{}); ok {
+			return ""
+		}
+		return token
+	})
+}
+
+// promptTokenStyle returns the style in effect after token name, or false
+// if name isn't a style token
+func promptTokenStyle(name string, current, base lipgloss.Style) (lipgloss.Style, bool) {
+	switch name {
+	case "reset":
+		return base, true
+	case "bold":
+		return current.Bold(true), true
+	}
+	if color, ok := promptColors[name]; ok {
+		return current.Foreground(styles.Color(color)), true
+	}
+	return current, false
+}
@@ -0,0 +1,150 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/polidog/slack-shell/internal/slack"
+)
+
+// messageSearch is the message search state shared by live and browse
+// mode. Methods that move the selection return the index of the message to
+// select, or -1 to keep the current one.
+type messageSearch struct {
+	searchMode    bool // true while typing the query
+	searchQuery   string
+	searchMatches []int // indices into messages that match searchQuery
+	searchIndex   int   // position of the current match in searchMatches
+}
+
+// startSearch enters search mode with an empty query
+func (s *messageSearch) startSearch() {
+	s.clearSearch()
+	s.searchMode = true
+}
+
+// clearSearch exits search mode and removes the query
+func (s *messageSearch) clearSearch() {
+	s.searchMode = false
+	s.searchQuery = ""
+	s.searchMatches = nil
+	s.searchIndex = 0
+}
+
+// handleSearchKey handles key events while typing a search query
+func (s *messageSearch) handleSearchKey(msg tea.KeyMsg, messages []slack.Message, userCache map[string]string) int {
+	switch msg.Type {
+	case tea.KeyEsc:
+		s.clearSearch()
+	case tea.KeyEnter:
+		// Stop typing but keep the query so n/N can cycle matches
+		s.searchMode = false
+		if len(s.searchMatches) == 0 {
+			s.searchQuery = ""
+		}
+	case tea.KeyBackspace:
+		runes := []rune(s.searchQuery)
+		if len(runes) > 0 {
+			s.searchQuery = string(runes[:len(runes)-1])
+			return s.updateSearchMatches(messages, userCache)
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		s.searchQuery += string(msg.Runes)
+		return s.updateSearchMatches(messages, userCache)
+	}
+	return -1
+}
+
+// findSearchMatches sets searchMatches to the messages matching the query
+func (s *messageSearch) findSearchMatches(messages []slack.Message, userCache map[string]string) {
+	s.searchMatches = nil
+	for i, msg := range messages {
+		if messageMatchesQuery(msg, s.searchQuery, userCache) {
+			s.searchMatches = append(s.searchMatches, i)
+		}
+	}
+}
+
+// updateSearchMatches recomputes the matches and returns the newest one
+func (s *messageSearch) updateSearchMatches(messages []slack.Message, userCache map[string]string) int {
+	s.findSearchMatches(messages, userCache)
+	s.searchIndex = 0
+	if len(s.searchMatches) == 0 {
+		return -1
+	}
+	s.searchIndex = len(s.searchMatches) - 1
+	return s.searchMatches[s.searchIndex]
+}
+
+// jumpToMatch returns the previous (-1, older) or next (+1, newer) search
+// match relative to the selected message, wrapping around
+func (s *messageSearch) jumpToMatch(direction, selected int, messages []slack.Message, userCache map[string]string) int {
+	// Messages may have changed since the last search
+	s.findSearchMatches(messages, userCache)
+	if len(s.searchMatches) == 0 {
+		return -1
+	}
+
+	next := -1
+	if direction < 0 {
+		for i := len(s.searchMatches) - 1; i >= 0; i-- {
+			if s.searchMatches[i] < selected {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			next = len(s.searchMatches) - 1
+		}
+	} else {
+		for i, idx := range s.searchMatches {
+			if idx > selected {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			next = 0
+		}
+	}
+
+	s.searchIndex = next
+	return s.searchMatches[next]
+}
+
+// renderSearchBar renders the query with the position of the current match
+func (s *messageSearch) renderSearchBar(style lipgloss.Style) string {
+	cursor := ""
+	if s.searchMode {
+		cursor = "▏"
+	}
+	status := ""
+	if s.searchQuery != "" {
+		if len(s.searchMatches) == 0 {
+			status = " (no matches)"
+		} else {
+			status = fmt.Sprintf(" (%d/%d)", s.searchIndex+1, len(s.searchMatches))
+		}
+	}
+	return style.Render("/" + s.searchQuery + cursor + status)
+}
+
+// messageMatchesQuery reports whether msg's rendered text or user name
+// contains query, ignoring case
+func messageMatchesQuery(msg slack.Message, query string, userCache map[string]string) bool {
+	if query == "" {
+		return false
+	}
+	query = strings.ToLower(query)
+	text := ConvertEmoji(ResolveMentions(msg.Text, userCache))
+	if strings.Contains(strings.ToLower(text), query) {
+		return true
+	}
+	userName := msg.UserName
+	if userName == "" {
+		userName = userCache[msg.User]
+	}
+	return userName != "" && strings.Contains(strings.ToLower(userName), query)
+}
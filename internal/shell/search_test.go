@@ -0,0 +1,42 @@
+package shell
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/polidog/slack-shell/internal/slack"
+)
+
+func TestMessageSearch(t *testing.T) {
+	messages := []slack.Message{
+		{Text: "deploy started"},
+		{Text: "lunch?"},
+		{Text: "Deploy finished"},
+		{Text: "hi", User: "U1"},
+	}
+	userCache := map[string]string{"U1": "deployer"}
+
+	var s messageSearch
+	s.startSearch()
+	if got := s.handleSearchKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("deploy")}, messages, userCache); got != 3 {
+		t.Errorf("typing selects %d; want the newest match 3", got)
+	}
+	if got := s.handleSearchKey(tea.KeyMsg{Type: tea.KeyEnter}, messages, userCache); got != -1 || s.searchMode || s.searchQuery != "deploy" {
+		t.Errorf("enter = %d, mode %v, query %q; want -1, false, %q", got, s.searchMode, s.searchQuery, "deploy")
+	}
+
+	// n goes to older matches and wraps to the newest
+	for _, tt := range []struct{ selected, want int }{{3, 2}, {2, 0}, {0, 3}} {
+		if got := s.jumpToMatch(-1, tt.selected, messages, userCache); got != tt.want {
+			t.Errorf("jumpToMatch(-1) from %d = %d; want %d", tt.selected, got, tt.want)
+		}
+	}
+	if got := s.jumpToMatch(1, 3, messages, userCache); got != 0 {
+		t.Errorf("jumpToMatch(1) from the newest = %d; want 0", got)
+	}
+
+	s.startSearch()
+	if got := s.handleSearchKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("xyz")}, messages, userCache); got != -1 {
+		t.Errorf("no match selects %d; want -1", got)
+	}
+}
@@ -0,0 +1,190 @@
+package shell
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/polidog/slack-shell/internal/keymap"
+	"github.com/polidog/slack-shell/internal/slack"
+)
+
+// SplitLiveModel shows two live panes side by side. Keys go to the active
+// pane; the next_panel key (Tab by default) switches panes when the active
+// pane isn't taking text input.
+type SplitLiveModel struct {
+	panes         []*LiveModel
+	active        int
+	width, height int
+}
+
+// splitPaneMsg carries the result of a pane's command back to that pane,
+// since live messages don't say which channel they were loaded for
+type splitPaneMsg struct {
+	Pane int
+	Msg  tea.Msg
+}
+
+// NewSplitLiveModel creates a split view of the given live panes
+func NewSplitLiveModel(panes ...*LiveModel) *SplitLiveModel {
+	return &SplitLiveModel{panes: panes}
+}
+
+// Init loads messages for every pane
+func (m *SplitLiveModel) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(m.panes))
+	for i, pane := range m.panes {
+		cmds = append(cmds, wrapPaneCmd(i, pane.Init()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// wrapPaneCmd tags the messages produced by cmd with the pane index
+func wrapPaneCmd(pane int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			cmds := make([]tea.Cmd, len(batch))
+			for i, c := range batch {
+				cmds[i] = wrapPaneCmd(pane, c)
+			}
+			return tea.BatchMsg(cmds)
+		}
+		return splitPaneMsg{Pane: pane, Msg: msg}
+	}
+}
+
+// Update routes messages to the panes
+func (m *SplitLiveModel) Update(msg tea.Msg) (*SplitLiveModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case splitPaneMsg:
+		if msg.Pane < 0 || msg.Pane >= len(m.panes) {
+			return m, nil
+		}
+		return m, m.updatePane(msg.Pane, msg.Msg)
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.resizePanes()
+		return m, nil
+
+	case tea.KeyMsg:
+		active := m.panes[m.active]
+		if active.keymap.MatchKey(msg, keymap.ActionNextPanel) && !active.IsInInputMode() && !active.searchMode {
+			m.active = (m.active + 1) % len(m.panes)
+			return m, nil
+		}
+		return m, m.updatePane(m.active, msg)
+	}
+
+	return m, nil
+}
+
+func (m *SplitLiveModel) updatePane(i int, msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.panes[i], cmd = m.panes[i].Update(msg)
+	return wrapPaneCmd(i, cmd)
+}
+
+// resizePanes divides the screen between the panes, leaving room for borders
+func (m *SplitLiveModel) resizePanes() {
+	if len(m.panes) == 0 {
+		return
+	}
+	paneWidth := m.width/len(m.panes) - 2
+	for i := range m.panes {
+		m.panes[i], _ = m.panes[i].Update(tea.WindowSizeMsg{Width: paneWidth, Height: m.height - 2})
+	}
+}
+
+// View renders the panes side by side
+func (m *SplitLiveModel) View() string {
+	views := make([]string, 0, len(m.panes))
+	for i, pane := range m.panes {
+		style := splitPaneStyle
+		if i == m.active {
+			style = splitActivePaneStyle
+		}
+		style = style.Width(pane.width).Height(pane.height).MaxHeight(pane.height + 2)
+		views = append(views, style.Render(pane.View()))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, views...)
+}
+
+// ShouldExit returns true if the active pane asks to leave live mode
+func (m *SplitLiveModel) ShouldExit(msg tea.KeyMsg) bool {
+	return m.panes[m.active].ShouldExit(msg)
+}
+
+// ChannelIDs returns the channels on screen, including peeked ones
+func (m *SplitLiveModel) ChannelIDs() []string {
+	var ids []string
+	for _, pane := range m.panes {
+		ids = append(ids, pane.GetChannelID())
+		if pane.IsPeekMode() {
+			ids = append(ids, pane.GetPeekChannelID())
+		}
+	}
+	return ids
+}
+
+// AddIncomingMessage adds a realtime message to every pane showing its
+// channel. It returns false if no pane shows the channel.
+func (m *SplitLiveModel) AddIncomingMessage(channelID, userID, userName, text, timestamp, threadTS string) bool {
+	found := false
+	for _, pane := range m.panes {
+		if channelID == pane.GetChannelID() {
+			pane.AddIncomingMessage(channelID, userID, userName, text, timestamp, threadTS)
+			found = true
+		} else if pane.IsPeekMode() && channelID == pane.GetPeekChannelID() {
+			pane.AddPeekIncomingMessage(channelID, userID, userName, text, timestamp, threadTS)
+			found = true
+		}
+	}
+	return found
+}
+
+// AddNotification shows a notification for another channel in the active pane
+func (m *SplitLiveModel) AddNotification(item NotificationItem) {
+	m.panes[m.active].AddNotification(item)
+}
+
+// RemoveDeletedMessage removes a deleted message from the panes
+func (m *SplitLiveModel) RemoveDeletedMessage(channelID, deletedTimestamp string) {
+	for _, pane := range m.panes {
+		if channelID == pane.GetChannelID() {
+			pane.RemoveDeletedMessage(channelID, deletedTimestamp)
+		} else if pane.IsPeekMode() && channelID == pane.GetPeekChannelID() {
+			pane.RemovePeekDeletedMessage(channelID, deletedTimestamp)
+		}
+	}
+}
+
+// UpdateEditedMessage updates an edited message in the panes
+func (m *SplitLiveModel) UpdateEditedMessage(channelID, timestamp, text string) {
+	for _, pane := range m.panes {
+		if channelID == pane.GetChannelID() {
+			pane.UpdateEditedMessage(channelID, timestamp, text)
+		} else if pane.IsPeekMode() && channelID == pane.GetPeekChannelID() {
+			pane.UpdatePeekEditedMessage(channelID, timestamp, text)
+		}
+	}
+}
+
+// ApplyReaction updates reaction counts in the panes
+func (m *SplitLiveModel) ApplyReaction(evt slack.ReactionEvent) {
+	for _, pane := range m.panes {
+		pane.ApplyReaction(evt)
+	}
+}
+
+// AddTypingUser shows a typing user in the panes showing that channel
+func (m *SplitLiveModel) AddTypingUser(evt slack.TypingEvent) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(m.panes))
+	for i, pane := range m.panes {
+		cmds = append(cmds, wrapPaneCmd(i, pane.AddTypingUser(evt)))
+	}
+	return tea.Batch(cmds...)
+}
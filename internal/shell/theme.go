@@ -0,0 +1,115 @@
+package shell
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/polidog/slack-shell/internal/ui/styles"
+)
+
+// Styles whose colors come from the theme (see applyTheme)
+var (
+	outputStyle    lipgloss.Style
+	errorStyle     lipgloss.Style
+	initErrorStyle lipgloss.Style
+	modeStyle      lipgloss.Style
+
+	liveSelectedStyle lipgloss.Style
+	liveNormalStyle   lipgloss.Style
+	liveHeaderStyle   lipgloss.Style
+	liveHelpStyle     lipgloss.Style
+
+	liveThreadStyle      lipgloss.Style
+	liveSearchBarStyle   lipgloss.Style
+	liveSearchMatchStyle lipgloss.Style
+	liveNotifyPanelStyle lipgloss.Style
+	liveNotifyBarStyle   lipgloss.Style
+	liveNewMsgStyle      lipgloss.Style
+	livePeekHeaderStyle  lipgloss.Style
+
+	browseSelectedStyle    lipgloss.Style
+	browseNormalStyle      lipgloss.Style
+	browseHeaderStyle      lipgloss.Style
+	browseHelpStyle        lipgloss.Style
+	browseThreadStyle      lipgloss.Style
+	browseSearchBarStyle   lipgloss.Style
+	browseSearchMatchStyle lipgloss.Style
+
+	splitPaneStyle       lipgloss.Style
+	splitActivePaneStyle lipgloss.Style
+
+	notificationStyle     lipgloss.Style
+	mentionHighlightStyle lipgloss.Style
+
+	channelInfoTitleStyle   lipgloss.Style
+	channelInfoPrivateStyle lipgloss.Style
+	channelInfoLabelStyle   lipgloss.Style
+	channelInfoValueStyle   lipgloss.Style
+	channelInfoCountStyle   lipgloss.Style
+	channelInfoUserStyle    lipgloss.Style
+	channelInfoSectionStyle lipgloss.Style
+)
+
+func init() {
+	applyTheme(styles.CurrentTheme())
+}
+
+// applyTheme rebuilds the themed styles from t
+func applyTheme(t styles.Theme) {
+	foreground := lipgloss.NewStyle().Foreground(styles.Color(t.Foreground))
+	selected := lipgloss.NewStyle().
+		Background(styles.Color(t.Selected)).
+		Foreground(styles.Color(t.SelectedForeground))
+	header := lipgloss.NewStyle().Foreground(styles.Color(t.Header))
+	help := lipgloss.NewStyle().Foreground(styles.Color(t.Help))
+	thread := lipgloss.NewStyle().Foreground(styles.Color(t.Accent)).PaddingLeft(2)
+	searchBar := lipgloss.NewStyle().
+		Foreground(styles.Color(t.SelectedForeground)).
+		Background(styles.Color(t.Accent))
+	match := lipgloss.NewStyle().Foreground(styles.Color(t.Match))
+	success := lipgloss.NewStyle().Foreground(styles.Color(t.Success))
+
+	outputStyle = foreground
+	errorStyle = lipgloss.NewStyle().Foreground(styles.Color(t.Error))
+	initErrorStyle = errorStyle.Bold(true)
+	modeStyle = header
+
+	liveSelectedStyle = selected
+	liveNormalStyle = foreground
+	liveHeaderStyle = header.Bold(true)
+	liveHelpStyle = help
+	liveThreadStyle = thread
+	liveSearchBarStyle = searchBar
+	liveSearchMatchStyle = match
+	liveNotifyPanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Color(t.Accent)).
+		Padding(0, 1)
+	liveNotifyBarStyle = lipgloss.NewStyle().
+		Foreground(styles.Color(t.SelectedForeground)).
+		Background(styles.Color(t.NotifyBar))
+	liveNewMsgStyle = success
+	livePeekHeaderStyle = header.Bold(true)
+
+	browseSelectedStyle = selected
+	browseNormalStyle = foreground
+	browseHeaderStyle = header.Bold(true)
+	browseHelpStyle = help
+	browseThreadStyle = thread
+	browseSearchBarStyle = searchBar
+	browseSearchMatchStyle = match
+
+	splitPaneStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Color(t.Help))
+	splitActivePaneStyle = splitPaneStyle.BorderForeground(styles.Color(t.Accent))
+
+	notificationStyle = selected.Padding(0, 1)
+	mentionHighlightStyle = lipgloss.NewStyle().Foreground(styles.Color(t.Mention)).Bold(true)
+
+	channelInfoTitleStyle = lipgloss.NewStyle().Foreground(styles.Color(t.Title)).Bold(true)
+	channelInfoPrivateStyle = match.Bold(true)
+	channelInfoLabelStyle = help
+	channelInfoValueStyle = foreground
+	channelInfoCountStyle = success
+	channelInfoUserStyle = lipgloss.NewStyle().Foreground(styles.Color(t.User))
+	channelInfoSectionStyle = header.Bold(true)
+}
@@ -2,10 +2,14 @@ package slack
 
 import (
 	"strings"
+	"time"
 
 	"github.com/slack-go/slack"
 )
 
+// usersListTTL is how long the workspace user list is reused
+const usersListTTL = 5 * time.Minute
+
 type Channel struct {
 	ID          string
 	Name        string
@@ -173,7 +177,7 @@ func (c *Client) GetUsersInfo(userIDs []string) (*[]slack.User, error) {
 // Prioritizes human users over bots when names match
 func (c *Client) GetUserByName(name string) (userID string, userName string, err error) {
 	// Use users.list API to search for users
-	users, err := c.api.GetUsers()
+	users, err := c.listUsers()
 	if err != nil {
 		return "", "", err
 	}
@@ -216,6 +220,90 @@ func (c *Client) GetUserByName(name string) (userID string, userName string, err
 	return "", "", nil
 }
 
+// GetUserPresence returns a user's presence: "active" or "away"
+func (c *Client) GetUserPresence(userID string) (string, error) {
+	presence, err := c.api.GetUserPresence(userID)
+	if err != nil {
+		return "", err
+	}
+	return presence.Presence, nil
+}
+
+// User is a workspace user found by SearchUsers
+type User struct {
+	ID          string
+	Name        string
+	DisplayName string
+	RealName    string
+	IsBot       bool
+}
+
+// SearchUsers returns up to limit active users and bots whose username,
+// display name or real name contains query (case-insensitive). Human users
+// come before bots. A limit of 0 returns every match.
+func (c *Client) SearchUsers(query string, limit int) ([]User, error) {
+	users, err := c.listUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(query)
+	var humans, bots []User
+	for _, user := range users {
+		if user.Deleted {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(user.Name), query) &&
+			!strings.Contains(strings.ToLower(user.Profile.DisplayName), query) &&
+			!strings.Contains(strings.ToLower(user.RealName), query) {
+			continue
+		}
+		found := User{
+			ID:          user.ID,
+			Name:        user.Name,
+			DisplayName: user.Profile.DisplayName,
+			RealName:    user.RealName,
+			IsBot:       user.IsBot,
+		}
+		if user.IsBot {
+			bots = append(bots, found)
+		} else {
+			humans = append(humans, found)
+		}
+	}
+
+	matches := append(humans, bots...)
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// listUsers returns every user in the workspace. users.list is paginated
+// and rate limited, so the result is reused for a few minutes.
+func (c *Client) listUsers() ([]slack.User, error) {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+
+	if c.usersList != nil && time.Since(c.usersListAt) < usersListTTL {
+		return c.usersList, nil
+	}
+	users, err := c.api.GetUsers()
+	if err != nil {
+		return nil, err
+	}
+	c.usersList = users
+	c.usersListAt = time.Now()
+	return users, nil
+}
+
+// ClearUsersList drops the cached workspace user list
+func (c *Client) ClearUsersList() {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+	c.usersList = nil
+}
+
 func (c *Client) CreateChannel(name string, isPrivate bool) (*Channel, error) {
 	channel, err := c.api.CreateConversation(slack.CreateConversationParams{
 		ChannelName: name,
@@ -278,6 +366,12 @@ func (c *Client) JoinChannel(channelID string) error {
 	return err
 }
 
+// JoinChannelAsUser joins a channel as the authenticated user
+func (c *Client) JoinChannelAsUser(channelID string) error {
+	_, _, _, err := c.api.JoinConversation(channelID)
+	return err
+}
+
 // LeaveChannel leaves a channel
 func (c *Client) LeaveChannel(channelID string) (bool, error) {
 	return c.api.LeaveConversation(channelID)
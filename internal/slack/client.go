@@ -2,6 +2,8 @@ package slack
 
 import (
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/slack-go/slack"
 )
@@ -15,6 +17,10 @@ type Client struct {
 	userName string
 	teamID   string
 	teamName string
+
+	usersMu     sync.Mutex
+	usersList   []slack.User // Cached users.list result, see listUsers
+	usersListAt time.Time
 }
 
 func NewClient(token string) (*Client, error) {
@@ -109,6 +115,23 @@ func (c *Client) HasBotToken() bool {
 	return c.botAPI != nil
 }
 
+// AuthTest calls auth.test with the user token to check that it is still
+// valid (unlike the values cached at startup)
+func (c *Client) AuthTest() error {
+	_, err := c.api.AuthTest()
+	return err
+}
+
+// BotAuthTest calls auth.test with the bot token. Returns nil if no bot
+// token is configured.
+func (c *Client) BotAuthTest() error {
+	if c.botAPI == nil {
+		return nil
+	}
+	_, err := c.botAPI.AuthTest()
+	return err
+}
+
 // BotAPI returns the bot API client (may be nil if no bot token)
 func (c *Client) BotAPI() *slack.Client {
 	return c.botAPI
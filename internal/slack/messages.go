@@ -13,6 +13,7 @@ type Message struct {
 	Text        string
 	ThreadTS    string
 	ReplyCount  int
+	LatestReply string // timestamp of the newest reply, for thread parents
 	Reactions   []Reaction
 	Attachments []Attachment
 	IsBot       bool
@@ -65,40 +66,7 @@ func (c *Client) GetMessagesWithPagination(channelID string, limit int, latest s
 
 	var messages []Message
 	for _, msg := range history.Messages {
-		// Get bot name from BotProfile or Username field
-		botName := msg.Username
-		if msg.BotProfile != nil && msg.BotProfile.Name != "" {
-			botName = msg.BotProfile.Name
-		}
-
-		m := Message{
-			Timestamp:  msg.Timestamp,
-			User:       msg.User,
-			Text:       msg.Text,
-			ThreadTS:   msg.ThreadTimestamp,
-			ReplyCount: msg.ReplyCount,
-			IsBot:      msg.BotID != "" && msg.User == "",
-			BotID:      msg.BotID,
-			BotName:    botName,
-		}
-
-		for _, r := range msg.Reactions {
-			m.Reactions = append(m.Reactions, Reaction{
-				Name:  r.Name,
-				Count: r.Count,
-				Users: r.Users,
-			})
-		}
-
-		for _, a := range msg.Attachments {
-			m.Attachments = append(m.Attachments, Attachment{
-				Title: a.Title,
-				Text:  a.Text,
-				Color: a.Color,
-			})
-		}
-
-		messages = append(messages, m)
+		messages = append(messages, convertMessage(msg))
 	}
 
 	// Reverse to show oldest first
@@ -112,6 +80,45 @@ func (c *Client) GetMessagesWithPagination(channelID string, limit int, latest s
 	}, nil
 }
 
+// convertMessage converts a message from the Slack API
+func convertMessage(msg slack.Message) Message {
+	// Get bot name from BotProfile or Username field
+	botName := msg.Username
+	if msg.BotProfile != nil && msg.BotProfile.Name != "" {
+		botName = msg.BotProfile.Name
+	}
+
+	m := Message{
+		Timestamp:   msg.Timestamp,
+		User:        msg.User,
+		Text:        msg.Text,
+		ThreadTS:    msg.ThreadTimestamp,
+		ReplyCount:  msg.ReplyCount,
+		LatestReply: msg.LatestReply,
+		IsBot:       msg.BotID != "" && msg.User == "",
+		BotID:       msg.BotID,
+		BotName:     botName,
+	}
+
+	for _, r := range msg.Reactions {
+		m.Reactions = append(m.Reactions, Reaction{
+			Name:  r.Name,
+			Count: r.Count,
+			Users: r.Users,
+		})
+	}
+
+	for _, a := range msg.Attachments {
+		m.Attachments = append(m.Attachments, Attachment{
+			Title: a.Title,
+			Text:  a.Text,
+			Color: a.Color,
+		})
+	}
+
+	return m
+}
+
 func (c *Client) PostMessage(channelID, text string) (string, error) {
 	_, ts, err := c.api.PostMessage(
 		channelID,
@@ -141,6 +148,11 @@ func (c *Client) UpdateMessage(channelID, timestamp, text string) error {
 	return err
 }
 
+// GetPermalink returns the Slack URL of a message
+func (c *Client) GetPermalink(channelID, timestamp string) (string, error) {
+	return c.api.GetPermalink(&slack.PermalinkParameters{Channel: channelID, Ts: timestamp})
+}
+
 func ParseTimestamp(ts string) time.Time {
 	// Slack timestamps are in format "1234567890.123456"
 	var sec, nsec int64
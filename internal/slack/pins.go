@@ -0,0 +1,34 @@
+package slack
+
+import (
+	"github.com/slack-go/slack"
+)
+
+// AddPin pins a message to a channel
+func (c *Client) AddPin(channelID, timestamp string) error {
+	return c.api.AddPin(channelID, slack.NewRefToMessage(channelID, timestamp))
+}
+
+// RemovePin unpins a message from a channel
+func (c *Client) RemovePin(channelID, timestamp string) error {
+	return c.api.RemovePin(channelID, slack.NewRefToMessage(channelID, timestamp))
+}
+
+// GetPins returns the pinned messages in a channel.
+// Pinned files and other non-message items are skipped.
+func (c *Client) GetPins(channelID string) ([]Message, error) {
+	items, _, err := c.api.ListPins(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []Message
+	for _, item := range items {
+		if item.Message == nil {
+			continue
+		}
+		messages = append(messages, convertMessage(*item.Message))
+	}
+
+	return messages, nil
+}
@@ -2,9 +2,12 @@ package slack
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
@@ -13,6 +16,12 @@ import (
 
 type EventHandler func(event interface{})
 
+const (
+	// Reconnect backoff bounds for Socket Mode
+	minReconnectDelay = 1 * time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
 type RealtimeClient struct {
 	client       *socketmode.Client
 	slackClient  *Client
@@ -20,6 +29,13 @@ type RealtimeClient struct {
 	ctx          context.Context
 	cancel       context.CancelFunc
 	debug        bool
+	maxRetries   int // 0 = retry forever
+
+	// Connection state, updated from the event loop
+	mu               sync.RWMutex
+	connected        bool
+	lastConnected    time.Time
+	lastDisconnected time.Time
 }
 
 type IncomingMessage struct {
@@ -36,6 +52,28 @@ type DeletedMessage struct {
 	DeletedTimestamp string
 }
 
+// EditedMessage represents a message whose text was changed
+type EditedMessage struct {
+	ChannelID string
+	Timestamp string // Timestamp of the edited message
+	Text      string
+}
+
+// ReactionEvent represents a reaction added to or removed from a message
+type ReactionEvent struct {
+	ChannelID string
+	Timestamp string // Timestamp of the reacted message
+	UserID    string
+	Reaction  string
+	Added     bool
+}
+
+// TypingEvent is sent when a user starts typing in a channel
+type TypingEvent struct {
+	ChannelID string
+	UserID    string
+}
+
 func NewRealtimeClient(slackClient *Client, appToken string, handler EventHandler, debug bool) *RealtimeClient {
 	// Create a new Slack client with app token for socket mode
 	opts := []slack.Option{
@@ -66,15 +104,105 @@ func NewRealtimeClient(slackClient *Client, appToken string, handler EventHandle
 	}
 }
 
+// SetMaxRetries sets how many consecutive reconnect attempts are made after
+// the connection drops. 0 retries forever.
+func (r *RealtimeClient) SetMaxRetries(n int) {
+	r.maxRetries = n
+}
+
+// Start connects to Socket Mode and blocks until Stop is called, reconnecting
+// with exponential backoff whenever the connection drops
 func (r *RealtimeClient) Start() error {
 	go r.handleEvents()
-	return r.client.Run()
+
+	delay := minReconnectDelay
+	retries := 0
+	for {
+		started := time.Now()
+		err := r.client.RunContext(r.ctx)
+		if r.ctx.Err() != nil {
+			return nil
+		}
+
+		// A connection that stayed up for a while starts a fresh backoff cycle
+		if time.Since(started) > maxReconnectDelay {
+			delay = minReconnectDelay
+			retries = 0
+		}
+
+		retries++
+		if r.maxRetries > 0 && retries > r.maxRetries {
+			return fmt.Errorf("giving up after %d reconnect attempts: %w", r.maxRetries, err)
+		}
+
+		r.setConnected(false)
+		if r.eventHandler != nil {
+			r.eventHandler("reconnecting")
+		}
+		if r.debug {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Socket Mode connection lost (%v), reconnecting in %s\n", err, delay)
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
 }
 
 func (r *RealtimeClient) Stop() {
 	r.cancel()
 }
 
+// Connected reports whether the Socket Mode connection is currently up
+func (r *RealtimeClient) Connected() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.connected
+}
+
+// LastConnected returns when the connection was last established (zero if never)
+func (r *RealtimeClient) LastConnected() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastConnected
+}
+
+// LastDisconnected returns when the connection was last lost (zero if never)
+func (r *RealtimeClient) LastDisconnected() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastDisconnected
+}
+
+// setConnected records a connection state transition and forwards it to the
+// event handler as "connected" or "disconnected"
+func (r *RealtimeClient) setConnected(connected bool) {
+	r.mu.Lock()
+	if connected {
+		r.lastConnected = time.Now()
+	} else if r.connected {
+		r.lastDisconnected = time.Now()
+	}
+	r.connected = connected
+	r.mu.Unlock()
+
+	if r.eventHandler == nil {
+		return
+	}
+	if connected {
+		r.eventHandler("connected")
+	} else {
+		r.eventHandler("disconnected")
+	}
+}
+
 func (r *RealtimeClient) handleEvents() {
 	for {
 		select {
@@ -123,6 +251,26 @@ func (r *RealtimeClient) handleEvents() {
 						continue
 					}
 
+					// Handle message_changed subtype (edits from any client)
+					if innerEvent.SubType == "message_changed" {
+						if innerEvent.Message == nil {
+							continue
+						}
+						if r.debug {
+							fmt.Fprintf(os.Stderr, "[DEBUG] Message changed: channel=%s ts=%s\n",
+								innerEvent.Channel, innerEvent.Message.Timestamp)
+						}
+						editedMsg := EditedMessage{
+							ChannelID: innerEvent.Channel,
+							Timestamp: innerEvent.Message.Timestamp,
+							Text:      innerEvent.Message.Text,
+						}
+						if r.eventHandler != nil {
+							r.eventHandler(editedMsg)
+						}
+						continue
+					}
+
 					// Skip other subtypes that don't represent new messages
 					if innerEvent.SubType != "" && innerEvent.SubType != "bot_message" {
 						continue
@@ -138,23 +286,84 @@ func (r *RealtimeClient) handleEvents() {
 					if r.eventHandler != nil {
 						r.eventHandler(msg)
 					}
+
+				case *slackevents.ReactionAddedEvent:
+					r.handleReaction(reactionEventFrom(innerEvent.Item, innerEvent.User, innerEvent.Reaction, true))
+
+				case *slackevents.ReactionRemovedEvent:
+					r.handleReaction(reactionEventFrom(innerEvent.Item, innerEvent.User, innerEvent.Reaction, false))
 				}
 
+			case socketmode.EventTypeErrorBadMessage:
+				r.handleUnparsedEvent(evt.Data)
+
 			case socketmode.EventTypeConnectionError:
 				if r.eventHandler != nil {
 					r.eventHandler(evt.Data)
 				}
 
 			case socketmode.EventTypeConnected:
-				if r.eventHandler != nil {
-					r.eventHandler("connected")
-				}
+				r.setConnected(true)
 
 			case socketmode.EventTypeDisconnect:
-				if r.eventHandler != nil {
-					r.eventHandler("disconnected")
-				}
+				r.setConnected(false)
 			}
 		}
 	}
 }
+
+// reactionEventFrom builds a ReactionEvent from a reaction_added/removed item.
+// Returns nil for reactions on non-message items such as files.
+func reactionEventFrom(item slackevents.Item, userID, reaction string, added bool) *ReactionEvent {
+	if item.Type != "message" {
+		return nil
+	}
+	return &ReactionEvent{
+		ChannelID: item.Channel,
+		Timestamp: item.Timestamp,
+		UserID:    userID,
+		Reaction:  reaction,
+		Added:     added,
+	}
+}
+
+// handleUnparsedEvent handles Events API events that slack-go has no type
+// for, such as user_typing. They arrive as bad messages and are not acked
+// by the socketmode client.
+func (r *RealtimeClient) handleUnparsedEvent(data interface{}) {
+	bad, ok := data.(*socketmode.ErrorBadMessage)
+	if !ok {
+		return
+	}
+	var req socketmode.Request
+	if err := json.Unmarshal(bad.Message, &req); err != nil || req.Type != socketmode.RequestTypeEventsAPI {
+		return
+	}
+	r.client.Ack(req)
+
+	var payload struct {
+		Event slack.UserTypingEvent `json:"event"`
+	}
+	if err := json.Unmarshal(req.Payload, &payload); err != nil || payload.Event.Type != "user_typing" {
+		return
+	}
+	if r.debug {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Typing event: channel=%s user=%s\n", payload.Event.Channel, payload.Event.User)
+	}
+	if r.eventHandler != nil {
+		r.eventHandler(TypingEvent{ChannelID: payload.Event.Channel, UserID: payload.Event.User})
+	}
+}
+
+func (r *RealtimeClient) handleReaction(evt *ReactionEvent) {
+	if evt == nil {
+		return
+	}
+	if r.debug {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Reaction event: channel=%s ts=%s reaction=%s added=%v\n",
+			evt.ChannelID, evt.Timestamp, evt.Reaction, evt.Added)
+	}
+	if r.eventHandler != nil {
+		r.eventHandler(*evt)
+	}
+}
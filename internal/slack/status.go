@@ -0,0 +1,89 @@
+package slack
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// ErrUserTokenRequired is returned by operations that only work with a user token
+var ErrUserTokenRequired = errors.New("this command requires a user token (xoxp-)")
+
+// IsUserToken reports whether the client authenticates with a user token
+func (c *Client) IsUserToken() bool {
+	return strings.HasPrefix(c.token, "xoxp-")
+}
+
+// SetUserStatus sets the user's custom status. A zero expiry never expires.
+func (c *Client) SetUserStatus(text, emoji string, expiry time.Time) error {
+	if !c.IsUserToken() {
+		return ErrUserTokenRequired
+	}
+	var expiration int64
+	if !expiry.IsZero() {
+		expiration = expiry.Unix()
+	}
+	return c.api.SetUserCustomStatus(text, emoji, expiration)
+}
+
+// GetUserStatus returns the user's custom status. expiry is zero if the
+// status doesn't expire.
+func (c *Client) GetUserStatus() (text, emoji string, expiry time.Time, err error) {
+	profile, err := c.api.GetUserProfile(&slack.GetUserProfileParameters{UserID: c.userID})
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	if profile.StatusExpiration > 0 {
+		expiry = time.Unix(int64(profile.StatusExpiration), 0)
+	}
+	return profile.StatusText, profile.StatusEmoji, expiry, nil
+}
+
+// ClearUserStatus removes the user's custom status
+func (c *Client) ClearUserStatus() error {
+	if !c.IsUserToken() {
+		return ErrUserTokenRequired
+	}
+	return c.api.UnsetUserCustomStatus()
+}
+
+// GetSlackDND returns when the user's Slack Do Not Disturb snooze ends,
+// or a zero time if it isn't snoozing
+func (c *Client) GetSlackDND() (time.Time, error) {
+	status, err := c.api.GetDNDInfo(nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !status.SnoozeEnabled || status.SnoozeEndTime == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(int64(status.SnoozeEndTime), 0), nil
+}
+
+// SetSlackDND snoozes Slack notifications on every device for minutes
+func (c *Client) SetSlackDND(minutes int) error {
+	if !c.IsUserToken() {
+		return ErrUserTokenRequired
+	}
+	_, err := c.api.SetSnooze(minutes)
+	return err
+}
+
+// EndSlackDND ends the Slack Do Not Disturb snooze
+func (c *Client) EndSlackDND() error {
+	if !c.IsUserToken() {
+		return ErrUserTokenRequired
+	}
+	_, err := c.api.EndSnooze()
+	return err
+}
+
+// SetPresence sets the user's presence to "auto" or "away"
+func (c *Client) SetPresence(presence string) error {
+	if !c.IsUserToken() {
+		return ErrUserTokenRequired
+	}
+	return c.api.SetUserPresence(presence)
+}
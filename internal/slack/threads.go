@@ -1,6 +1,8 @@
 package slack
 
 import (
+	"fmt"
+
 	"github.com/slack-go/slack"
 )
 
@@ -18,41 +20,33 @@ func (c *Client) GetThreadReplies(channelID, threadTS string) ([]Message, error)
 
 	var messages []Message
 	for _, msg := range msgs {
-		// Get bot name from BotProfile or Username field
-		botName := msg.Username
-		if msg.BotProfile != nil && msg.BotProfile.Name != "" {
-			botName = msg.BotProfile.Name
-		}
+		messages = append(messages, convertMessage(msg))
+	}
 
-		m := Message{
-			Timestamp:  msg.Timestamp,
-			User:       msg.User,
-			Text:       msg.Text,
-			ThreadTS:   msg.ThreadTimestamp,
-			ReplyCount: msg.ReplyCount,
-			IsBot:      msg.BotID != "" && msg.User == "",
-			BotID:      msg.BotID,
-			BotName:    botName,
-		}
+	return messages, nil
+}
 
-		for _, r := range msg.Reactions {
-			m.Reactions = append(m.Reactions, Reaction{
-				Name:  r.Name,
-				Count: r.Count,
-				Users: r.Users,
-			})
-		}
+// GetThreadReply fetches the single reply with timestamp replyTS from the
+// thread threadTS, such as a parent's LatestReply
+func (c *Client) GetThreadReply(channelID, threadTS, replyTS string) (*Message, error) {
+	params := &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: threadTS,
+		Oldest:    replyTS,
+		Inclusive: true,
+		// The parent may be returned as well
+		Limit: 2,
+	}
 
-		for _, a := range msg.Attachments {
-			m.Attachments = append(m.Attachments, Attachment{
-				Title: a.Title,
-				Text:  a.Text,
-				Color: a.Color,
-			})
+	msgs, _, _, err := c.api.GetConversationReplies(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range msgs {
+		if msg.Timestamp == replyTS {
+			m := convertMessage(msg)
+			return &m, nil
 		}
-
-		messages = append(messages, m)
 	}
-
-	return messages, nil
+	return nil, fmt.Errorf("reply not found: %s", replyTS)
 }
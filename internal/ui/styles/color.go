@@ -0,0 +1,78 @@
+package styles
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Color normalizes a color value for lipgloss. ANSI palette indices ("4",
+// "252") are passed through; hex colors are accepted with or without a
+// leading '#' and in short form ("#abc" becomes "#aabbcc").
+func Color(value string) lipgloss.Color {
+	value = strings.TrimSpace(value)
+	hex := strings.TrimPrefix(value, "#")
+	if !isHex(hex) || (!strings.HasPrefix(value, "#") && isDigits(hex)) {
+		return lipgloss.Color(value)
+	}
+
+	switch len(hex) {
+	case 3:
+		var sb strings.Builder
+		for _, c := range hex {
+			sb.WriteRune(c)
+			sb.WriteRune(c)
+		}
+		return lipgloss.Color("#" + strings.ToLower(sb.String()))
+	case 6:
+		return lipgloss.Color("#" + strings.ToLower(hex))
+	}
+	return lipgloss.Color(value)
+}
+
+// ValidColor reports whether value is a color Color understands: an ANSI
+// palette index from 0 to 255 or a 3- or 6-digit hex color
+func ValidColor(value string) bool {
+	value = strings.TrimSpace(value)
+	if isDigits(value) && value != "" {
+		n, err := strconv.Atoi(value)
+		return err == nil && n <= 255
+	}
+	hex := strings.TrimPrefix(value, "#")
+	return isHex(hex) && (len(hex) == 3 || len(hex) == 6)
+}
+
+// NoColorRequested reports whether the NO_COLOR environment variable is set
+// (see https://no-color.org)
+func NoColorRequested() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// DisableColor renders all styles as plain text from now on
+func DisableColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+func isHex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -6,18 +6,18 @@ import (
 
 var (
 	// Colors
-	Primary     = lipgloss.Color("#4A154B") // Slack purple
-	Secondary   = lipgloss.Color("#36C5F0") // Slack blue
-	Accent      = lipgloss.Color("#2EB67D") // Slack green
-	Warning     = lipgloss.Color("#ECB22E") // Slack yellow
-	Error       = lipgloss.Color("#E01E5A") // Slack red
-	Muted       = lipgloss.Color("#616061")
-	Background  = lipgloss.Color("#1A1D21")
-	Surface     = lipgloss.Color("#222529")
-	Border      = lipgloss.Color("#383838")
-	Text        = lipgloss.Color("#D1D2D3")
-	TextMuted   = lipgloss.Color("#9B9B9B")
-	Highlight   = lipgloss.Color("#1264A3")
+	Primary     = Color("#4A154B") // Slack purple
+	Secondary   = Color("#36C5F0") // Slack blue
+	Accent      = Color("#2EB67D") // Slack green
+	Warning     = Color("#ECB22E") // Slack yellow
+	Error       = Color("#E01E5A") // Slack red
+	Muted       = Color("#616061")
+	Background  = Color("#1A1D21")
+	Surface     = Color("#222529")
+	Border      = Color("#383838")
+	Text        = Color("#D1D2D3")
+	TextMuted   = Color("#9B9B9B")
+	Highlight   = Color("#1264A3")
 
 	// Base styles
 	BaseStyle = lipgloss.NewStyle().
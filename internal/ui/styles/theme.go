@@ -0,0 +1,108 @@
+package styles
+
+import "github.com/charmbracelet/lipgloss"
+
+// Theme holds the colors of the shell UI. Each value is anything Color
+// accepts: an ANSI palette index ("252") or a hex color ("#c0c0c0").
+type Theme struct {
+	Foreground         string // message and command output text
+	Selected           string // background of the selected message
+	SelectedForeground string // text of the selected message
+	Header             string // mode headers and status lines
+	Help               string // key help lines
+	Error              string // error messages
+	Mention            string // mentions of you in message text
+	Accent             string // thread replies, search bars and active borders
+	Match              string // search matches in message text
+	Title              string // channel names in channel info
+	Success            string // new messages and counts
+	User               string // user names in channel info
+	NotifyBar          string // background of the notification bar
+}
+
+var currentTheme = DefaultTheme()
+
+// DefaultTheme returns the built-in colors, chosen for dark terminals
+func DefaultTheme() Theme {
+	return Theme{
+		Foreground:         "252",
+		Selected:           "4",
+		SelectedForeground: "15",
+		Header:             "3",
+		Help:               "8",
+		Error:              "9",
+		Mention:            "13",
+		Accent:             "6",
+		Match:              "11",
+		Title:              "14",
+		Success:            "10",
+		User:               "9",
+		NotifyBar:          "8",
+	}
+}
+
+// LightTheme returns colors readable on light terminal backgrounds
+func LightTheme() Theme {
+	return Theme{
+		Foreground:         "235",
+		Selected:           "153",
+		SelectedForeground: "0",
+		Header:             "94",
+		Help:               "244",
+		Error:              "160",
+		Mention:            "90",
+		Accent:             "30",
+		Match:              "130",
+		Title:              "24",
+		Success:            "28",
+		User:               "124",
+		NotifyBar:          "252",
+	}
+}
+
+// ThemeByName returns a built-in theme: "dark", "light", or "auto", which
+// picks one from the terminal's background color (queried from the
+// terminal, falling back to $COLORFGBG). ok is false for unknown names.
+func ThemeByName(name string) (theme Theme, ok bool) {
+	switch name {
+	case "", "dark":
+		return DefaultTheme(), true
+	case "light":
+		return LightTheme(), true
+	case "auto":
+		if lipgloss.HasDarkBackground() {
+			return DefaultTheme(), true
+		}
+		return LightTheme(), true
+	}
+	return DefaultTheme(), false
+}
+
+// CurrentTheme returns the theme the shell renders with
+func CurrentTheme() Theme {
+	return currentTheme
+}
+
+// SetTheme replaces the current theme. Empty fields keep the default color.
+func SetTheme(t Theme) {
+	def := DefaultTheme()
+	fill := func(v *string, d string) {
+		if *v == "" {
+			*v = d
+		}
+	}
+	fill(&t.Foreground, def.Foreground)
+	fill(&t.Selected, def.Selected)
+	fill(&t.SelectedForeground, def.SelectedForeground)
+	fill(&t.Header, def.Header)
+	fill(&t.Help, def.Help)
+	fill(&t.Error, def.Error)
+	fill(&t.Mention, def.Mention)
+	fill(&t.Accent, def.Accent)
+	fill(&t.Match, def.Match)
+	fill(&t.Title, def.Title)
+	fill(&t.Success, def.Success)
+	fill(&t.User, def.User)
+	fill(&t.NotifyBar, def.NotifyBar)
+	currentTheme = t
+}
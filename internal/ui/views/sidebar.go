@@ -245,8 +245,8 @@ func (m SidebarModel) View() string {
 	// Search bar
 	if m.searchMode || m.searchQuery != "" {
 		searchStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("229")).
-			Background(lipgloss.Color("57")).
+			Foreground(styles.Color("229")).
+			Background(styles.Color("57")).
 			Padding(0, 1)
 		cursor := ""
 		if m.searchMode {